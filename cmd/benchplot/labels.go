@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// fileLabels returns the label of each named file. These are the
+// provided labels if any, or the shortest trailing part of each path
+// which distinguishes the files when comparing multiple files. Labels
+// must be unique and cannot contain ',' or '=', since these separate
+// and name the components of a group.
+func fileLabels(fileNames, labels []string) ([]string, error) {
+	if len(labels) == 0 {
+		if len(fileNames) < 2 {
+			return make([]string, len(fileNames)), nil
+		}
+
+		var err error
+		labels, err = distinctFileLabels(fileNames)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	seen := make(map[string]bool, len(labels))
+	for _, label := range labels {
+		if strings.ContainsAny(label, ",=") {
+			return nil, fmt.Errorf("invalid label '%s': labels cannot contain ',' or '=' (use -label to set the label of each file)", label)
+		}
+		if seen[label] {
+			return nil, fmt.Errorf("duplicate label: '%s'", label)
+		}
+		seen[label] = true
+	}
+	return labels, nil
+}
+
+// distinctFileLabels returns the base name of each named file, or
+// "stdin" for "-", extended by parent directories until no two files
+// share a label.
+func distinctFileLabels(fileNames []string) ([]string, error) {
+	var (
+		labels = make([]string, len(fileNames))
+		elems  = make([][]string, len(fileNames))
+		depths = make([]int, len(fileNames))
+	)
+	for i, name := range fileNames {
+		if name == "-" {
+			elems[i] = []string{"stdin"}
+		} else {
+			elems[i] = strings.Split(filepath.ToSlash(filepath.Clean(name)), "/")
+		}
+		depths[i] = 1
+	}
+
+	for {
+		byLabel := map[string][]int{}
+		for i, e := range elems {
+			labels[i] = filepath.Join(e[len(e)-depths[i]:]...)
+			byLabel[labels[i]] = append(byLabel[labels[i]], i)
+		}
+
+		distinct := true
+		for label, indices := range byLabel {
+			if len(indices) < 2 {
+				continue
+			}
+
+			extended := false
+			for _, i := range indices {
+				if depths[i] < len(elems[i]) {
+					depths[i]++
+					extended = true
+				}
+			}
+			if !extended {
+				return nil, fmt.Errorf("multiple input files labeled '%s' (use -label to set the label of each file)", label)
+			}
+			distinct = false
+		}
+		if distinct {
+			return labels, nil
+		}
+	}
+}
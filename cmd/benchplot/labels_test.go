@@ -0,0 +1,89 @@
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+var fileLabelsTests = map[string]struct {
+	fileNames      []string
+	labels         []string
+	expectedLabels []string
+	expectErr      bool
+}{
+	"single_file": {
+		fileNames:      []string{"bench.txt"},
+		expectedLabels: []string{""},
+	},
+	"single_file,label": {
+		fileNames:      []string{"bench.txt"},
+		labels:         []string{"main"},
+		expectedLabels: []string{"main"},
+	},
+	"base_names": {
+		fileNames:      []string{"results/old.txt", "results/new.txt", "-"},
+		expectedLabels: []string{"old.txt", "new.txt", "stdin"},
+	},
+	"same_base_name": {
+		fileNames:      []string{"main/bench.txt", "branch/bench.txt"},
+		expectedLabels: []string{filepath.Join("main", "bench.txt"), filepath.Join("branch", "bench.txt")},
+	},
+	"same_base_name,nested": {
+		fileNames:      []string{"a/main/bench.txt", "b/main/bench.txt", "other.txt"},
+		expectedLabels: []string{filepath.Join("a", "main", "bench.txt"), filepath.Join("b", "main", "bench.txt"), "other.txt"},
+	},
+	"same_base_name,shorter_path": {
+		fileNames:      []string{"bench.txt", "branch/bench.txt"},
+		expectedLabels: []string{"bench.txt", filepath.Join("branch", "bench.txt")},
+	},
+	"same_file": {
+		fileNames: []string{"bench.txt", "./bench.txt"},
+		expectErr: true,
+	},
+	"file_name_with_equals": {
+		fileNames: []string{"n=10.txt", "n=20.txt"},
+		expectErr: true,
+	},
+	"labels": {
+		fileNames:      []string{"main/bench.txt", "branch/bench.txt"},
+		labels:         []string{"main", "branch"},
+		expectedLabels: []string{"main", "branch"},
+	},
+	"duplicate_labels": {
+		fileNames: []string{"old.txt", "new.txt"},
+		labels:    []string{"main", "main"},
+		expectErr: true,
+	},
+	"label_with_comma": {
+		fileNames: []string{"old.txt", "new.txt"},
+		labels:    []string{"main,old", "main,new"},
+		expectErr: true,
+	},
+	"label_with_equals": {
+		fileNames: []string{"old.txt", "new.txt"},
+		labels:    []string{"version=1", "version=2"},
+		expectErr: true,
+	},
+}
+
+func TestFileLabels(t *testing.T) {
+	for testName, testCase := range fileLabelsTests {
+		t.Run(testName, func(t *testing.T) {
+			labels, err := fileLabels(testCase.fileNames, testCase.labels)
+			if err != nil {
+				if !testCase.expectErr {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			if testCase.expectErr {
+				t.Fatalf("unexpectedly no error")
+			}
+
+			if !reflect.DeepEqual(labels, testCase.expectedLabels) {
+				t.Errorf("unexpected labels (expected=%q, actual=%q)", testCase.expectedLabels, labels)
+			}
+		})
+	}
+}
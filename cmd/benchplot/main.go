@@ -1,34 +1,92 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
 
 	"github.com/ShawnROGrady/benchparse"
-	"github.com/ShawnROGrady/benchplot/gonum"
+	"github.com/ShawnROGrady/benchplot/export"
+	"github.com/ShawnROGrady/benchplot/input"
 	"github.com/ShawnROGrady/benchplot/plot"
+	"github.com/ShawnROGrady/benchplot/plot/plotter"
 )
 
+// allBenches is the benchmark name used to plot every benchmark.
+const allBenches = "all"
+
+// defaultDst is the output file name template used when plotting
+// a single benchmark.
+const defaultDst = "{{.Bench}}.png"
+
+// defaultBatchDst is the output file name template used when plotting
+// every benchmark.
+const defaultBatchDst = "{{.Bench}}_{{.X}}.png"
+
+// stdoutDst is the output file name used to draw the figure in
+// the terminal.
+const stdoutDst = "-"
+
+// The default size of the figure when drawing in the terminal.
+const (
+	defaultTermCols = 80
+	defaultTermRows = 24
+)
+
+// dstParams are the values available to the output file name template.
+type dstParams struct {
+	Bench string // the name of the benchmark
+	X     string // the name of the x-axis variable
+	Y     string // the name of the y-axis variable
+}
+
 func main() {
 	var (
-		benchName  = flag.String("bench", "", "The name of the benchmark to plot")
-		xName      = flag.String("x", "", "The name of the x-axis variable (an input to the benchmark)")
-		yName      = flag.String("y", plot.TimeName, "The name of the y-axis variable")
-		dstName    = flag.String("o", "", "The output file name with extension (if empty will be set to ${bench}.png)")
-		dstWidth   = flag.Float64("width", 500, "The width of the output figure")
-		dstHeight  = flag.Float64("height", 500, "The height of the output figure")
+		xName      = flag.String("x", "", fmt.Sprintf("The name of the x-axis variable (an input to the benchmark). Components of sub-benchmark names not of the form 'var_name=var_value' may be referred to by position as 'sub[0]', 'sub[1]', etc., and the value of GOMAXPROCS (the '-N' suffix of each name) as '%s'", plot.ProcsName))
+		yName      = flag.String("y", plot.TimeName, fmt.Sprintf("The name of the y-axis variable (options = %q, or the unit of a custom metric reported via testing.B.ReportMetric e.g. 'hits/op'). May also be an arithmetic expression using + - * / over these outputs and numeric inputs (e.g. 'time / n'), in which case custom metrics must be quoted and values are in their base units (e.g. ns/op)", []string{plot.RunsName, plot.TimeName, plot.NumAllocsName, plot.AllocBytesName, plot.AllocMBytesRate}))
+		dstName    = flag.String("o", "", fmt.Sprintf("The output file name with extension. May be a template using {{.Bench}}, {{.X}}, and {{.Y}}, in which case every benchmark matching a single -bench is plotted to a separate file (if empty will be set to ${bench}.png, or %q if plotting every benchmark). If \"-\" the figure is drawn in the terminal, if the extension is \".html\" an interactive page is written, or if it is %q the plotted data is written instead of a figure", defaultBatchDst, []export.Format{export.CSVFormat, export.JSONFormat}))
+		dataOut    = flag.String("data-out", "", fmt.Sprintf("The file name to write the plotted data to in addition to the figure, with extension %q. May be a template like -o", []export.Format{export.CSVFormat, export.JSONFormat}))
+		dstWidth   = flag.Float64("width", 500, fmt.Sprintf("The width of the output figure (in columns when drawing in the terminal, where the default is %d)", defaultTermCols))
+		dstHeight  = flag.Float64("height", 500, fmt.Sprintf("The height of the output figure (in rows when drawing in the terminal, where the default is %d)", defaultTermRows))
+		termOut    = flag.Bool("term", false, "Draw the figure in the terminal instead of saving it to a file (equivalent to '-o -')")
 		help       = flag.Bool("h", false, "Show this help message and exit")
 		topLegend  = flag.Bool("top-legend", false, "Display legend on top edge of plot (default is on bottom edge)")
 		leftLegend = flag.Bool("left-legend", false, "Display legend on left edge of plot (default is on right edge)")
+		yUnit      = flag.String("y-unit", "", "The unit to display the y-axis variable in (e.g. 'ms/op'). If empty a unit is selected based on the data. Cannot be used with -normalize-to")
+		xScale     = flag.String("x-scale", string(plotter.LinearScale), fmt.Sprintf("The scale of the x-axis (options = %q)", []plotter.Scale{plotter.LinearScale, plotter.Log2Scale, plotter.Log10Scale}))
+		yScale     = flag.String("y-scale", string(plotter.LinearScale), fmt.Sprintf("The scale of the y-axis (options = %q)", []plotter.Scale{plotter.LinearScale, plotter.Log2Scale, plotter.Log10Scale}))
+		normalize  = flag.String("normalize-to", "", "The group (e.g. 'impl=naive') or file label to use as a baseline. If set each y value is divided by the baseline's average at the same x")
+		alpha      = flag.Float64("significance", 0, "The significance level (e.g. 0.05) at which to test whether each group differs from its baseline at each x, using the Mann-Whitney U test. Significant differences are marked on the figure and a summary is printed. If 0 no test is performed")
+		compareTo  = flag.String("compare-to", "", "The group or file label to use as the baseline when testing significance (if empty the value of -normalize-to is used, otherwise the first input file or, when plotting multiple benchmarks, the first -bench, otherwise the first of exactly two groups)")
+		outliers   = flag.String("outliers", "", fmt.Sprintf("The rule used to discard outliers among the y values of each group at each x before plotting (options = %q). The number of discarded points is printed. If empty no points are discarded", []string{plot.IQROutliers, plot.MADOutliers}))
+		showOut    = flag.Bool("show-outliers", false, "Display points discarded by -outliers in scatter plots, in a muted style")
+		agg        = flag.String("agg", "", fmt.Sprintf("How the y values at each x are aggregated for %q plots, including the default plots (options = %q, or a percentile e.g. 'p90'). Cannot be used with %q plots, which always show the mean. If empty %q is used", plot.AggLineType, []string{plot.MeanAgg, plot.MedianAgg, plot.GeoMeanAgg, plot.MinAgg, plot.MaxAgg}, plot.AvgLineType, plot.MeanAgg))
+		namePat    = flag.String("name-pattern", "", "A regular expression with named captures matched against each sub-benchmark name (e.g. '^(?P<size>\\d+)/(?P<mode>\\w+)$' for 'BenchmarkFoo/1024/parallel'). Each capture becomes a variable which may be used like an input to the benchmark")
+		inFormat   = flag.String("input-format", string(input.AutoFormat), fmt.Sprintf("The format of the input files (options = %q). If %q files with the \".csv\" extension or a CSV header naming the 'name' column (e.g. on stdin) are read as CSV, JSON arrays and objects are read as test2json events if they are the output of 'go test -json' and as JSON records otherwise, and any other input as the output of 'go test -bench'", input.Formats, input.AutoFormat))
+		errKind    = flag.String("err-kind", plot.StdDevErr, fmt.Sprintf("The kind of error to display for %q plots (options = %q)", plot.AvgErrBarType, []string{plot.StdDevErr, plot.StdErrErr, plot.MinMaxErr}))
+		benchNames = &stringSliceFlag{}
 		groupBy    = &stringSliceFlag{}
 		plotTypes  = &stringSliceFlag{}
 		filterBy   = &stringSliceFlag{}
-		resFile    *os.File
+		labels     = &stringSliceFlag{}
+		columns    = &stringSliceFlag{}
+	)
+	flag.Var(benchNames, "bench", fmt.Sprintf("The name of, or a regular expression matching the name of, the top-level benchmark to plot (unlike 'go test -bench' the expression is not matched against each level of sub-benchmark names; use -filter-by to select sub-benchmarks). If %q or the output file name is a template every matching benchmark is plotted to a separate file. May be repeated to plot multiple benchmarks on the same figure", allBenches))
+	flag.Var(groupBy, "group-by", "The variables to group results by (an input to the benchmark, or the 'goos', 'goarch', 'pkg', or 'cpu' the benchmark was run with)")
+	flag.Var(columns, "column", fmt.Sprintf("The role of a column of %q input. Form: 'column=role', where role is one of %q or the unit of an output (e.g. 'ns/op' or 'hits/op'). Columns without a role have the role of their name if it is %q or %q, are outputs if named after a unit (e.g. 'B/op'), and are inputs otherwise. May be repeated", []input.Format{input.CSVFormat, input.JSONFormat}, []string{input.NameRole, input.RunsRole, input.InputRole, input.IgnoreRole}, input.NameRole, input.RunsRole))
+	flag.Var(labels, "label", "The labels of each input file when comparing multiple files, which must be unique and cannot contain ',' or '=' (if empty the shortest distinguishing part of each file path is used)")
+	flag.Var(
+		plotTypes, "plots",
+		fmt.Sprintf(
+			"The plots to generate (options = %q). If empty will default to %q for numeric data and %q for non-numeric data",
+			[]string{plot.ScatterType, plot.AvgLineType, plot.AggLineType, plot.BarType, plot.AvgErrBarType, plot.BoxType, plot.FitType, plot.ScalingType}, []string{plot.ScatterType, plot.AggLineType}, []string{plot.BarType},
+		),
 	)
-	flag.Var(groupBy, "group-by", "The variables to group results by (an input to the benchmark)")
-	flag.Var(plotTypes, "plots", fmt.Sprintf("The plots to generate (options = %q). If empty will default to %q for numeric data", []string{plot.ScatterType, plot.AvgLineType}, []string{plot.ScatterType, plot.AvgLineType}))
 	flag.Var(
 		filterBy, "filter-by",
 		fmt.Sprintf(
@@ -49,52 +107,199 @@ func main() {
 	if yName == nil || *yName == "" {
 		log.Fatal("y-axis variable is required")
 	}
-	if benchName == nil || *benchName == "" {
+	if len(*benchNames) == 0 || (*benchNames)[0] == "" {
 		log.Fatal("benchmark name is required")
 	}
+	if *termOut {
+		*dstName = stdoutDst
+	}
+	if *dstName == stdoutDst {
+		setFlags := map[string]bool{}
+		flag.Visit(func(f *flag.Flag) { setFlags[f.Name] = true })
+		if !setFlags["width"] {
+			*dstWidth = defaultTermCols
+		}
+		if !setFlags["height"] {
+			*dstHeight = defaultTermRows
+		}
+	}
+	batch := batchMode(*benchNames, *dstName)
 	if dstName == nil || *dstName == "" {
-		*dstName = fmt.Sprintf("%s.png", *benchName)
+		if batch {
+			*dstName = defaultBatchDst
+		} else {
+			*dstName = defaultDst
+		}
 	}
-
-	args := flag.Args()
-	if len(args) == 0 || args[0] == "-" {
-		resFile = os.Stdin
-	} else {
-		var err error
-		resFile, err = os.Open(args[0])
+	dstTmpl, err := template.New("dst").Parse(*dstName)
+	if err != nil {
+		log.Fatalf("error parsing output file name: %s", err)
+	}
+	_, dataDst := export.FormatOf(*dstName)
+	var dataOutTmpl *template.Template
+	if *dataOut != "" {
+		if _, ok := export.FormatOf(*dataOut); !ok {
+			log.Fatalf("unsupported data file extension: '%s' (options = %q)", filepath.Ext(*dataOut), []export.Format{export.CSVFormat, export.JSONFormat})
+		}
+		dataOutTmpl, err = template.New("data-out").Parse(*dataOut)
 		if err != nil {
-			log.Fatalf("error opening '%s': %s", args[0], err)
+			log.Fatalf("error parsing data file name: %s", err)
 		}
 	}
 
-	benches, err := benchparse.ParseBenchmarks(resFile)
+	args := flag.Args()
+	if len(args) == 0 {
+		args = []string{"-"}
+	}
+	if len(*labels) != 0 && len(*labels) != len(args) {
+		log.Fatalf("number of labels (%d) does not match number of input files (%d)", len(*labels), len(args))
+	}
+	fileLbls, err := fileLabels(args, *labels)
 	if err != nil {
-		log.Fatalf("error parsing input: %s", err)
+		log.Fatal(err)
 	}
 
-	bench, err := findBenchmark(benches, *benchName)
+	columnRoles := input.Columns{}
+	for _, c := range *columns {
+		i := strings.LastIndex(c, "=")
+		if i <= 0 {
+			log.Fatalf("column role not of form 'column=role': '%s'", c)
+		}
+		columnRoles[c[:i]] = c[i+1:]
+	}
+	if err := columnRoles.Validate(); err != nil {
+		log.Fatal(err)
+	}
+
+	benchSets := make([][]benchparse.Benchmark, len(args))
+	for i, arg := range args {
+		benchSets[i], err = parseFile(arg, input.Format(*inFormat), columnRoles)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	figures, err := figureBenchNames(allBenchNames(benchSets), *benchNames, batch)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	p := &gonum.Plotter{
-		TopLegend:  *topLegend,
-		LeftLegend: *leftLegend,
+	for _, names := range figures {
+		name := strings.Join(names, "_")
+		labeledBenches, err := labeledBenchmarks(benchSets, args, fileLbls, names)
+		if err != nil {
+			if batch {
+				log.Printf("skipping %s: %s", name, err)
+				continue
+			}
+			log.Fatal(err)
+		}
+
+		var (
+			plotters multiPlotter
+			img      figurePlotter
+			data     *export.Plotter
+		)
+		if dataDst || dataOutTmpl != nil {
+			data = &export.Plotter{}
+			plotters = append(plotters, data)
+		}
+		if !dataDst {
+			img = newFigurePlotter(*dstName, *topLegend, *leftLegend)
+			plotters = append(plotters, img)
+		}
+		if err := plot.Compare(labeledBenches, plotters, *xName, *yName, plot.WithGroupBy(*groupBy), plot.WithFilterBy(*filterBy), plot.WithNamePattern(*namePat), plot.WithPlotTypes(*plotTypes), plot.WithAggregation(*agg), plot.WithErrorKind(*errKind), plot.WithNormalizeTo(*normalize), plot.WithYUnit(*yUnit), plot.WithXScale(*xScale), plot.WithYScale(*yScale), plot.WithSignificance(*alpha), plot.WithCompareTo(*compareTo), plot.WithOutliers(*outliers), plot.WithShowOutliers(*showOut), plot.WithSummaryWriter{Writer: os.Stdout}); err != nil {
+			if batch && errors.Is(err, plot.ErrInputNotFound) {
+				log.Printf("skipping %s: %s", name, err)
+				continue
+			}
+			log.Fatalf("error plotting: %s", err)
+		}
+
+		params := dstParams{Bench: name, X: *xName, Y: *yName}
+		dst, err := executeName(dstTmpl, params)
+		if err != nil {
+			log.Fatalf("error creating output file name: %s", err)
+		}
+		if dataDst {
+			if err := data.Save(dst); err != nil {
+				log.Fatalf("error saving data: %s", err)
+			}
+		} else if err := img.Save(*dstWidth, *dstHeight, dst); err != nil {
+			log.Fatalf("error saving figure: %s", err)
+		}
+		if dataOutTmpl != nil {
+			dataName, err := executeName(dataOutTmpl, params)
+			if err != nil {
+				log.Fatalf("error creating data file name: %s", err)
+			}
+			if err := data.Save(dataName); err != nil {
+				log.Fatalf("error saving data: %s", err)
+			}
+		}
 	}
-	if err := plot.Benchmark(bench, p, *xName, *yName, plot.WithGroupBy(*groupBy), plot.WithFilterBy(*filterBy), plot.WithPlotTypes(*plotTypes)); err != nil {
-		log.Fatalf("error plotting: %s", err)
+}
+
+// executeName returns the output file name produced by the template.
+func executeName(tmpl *template.Template, params dstParams) (string, error) {
+	var name strings.Builder
+	if err := tmpl.Execute(&name, params); err != nil {
+		return "", err
 	}
+	return name.String(), nil
+}
 
-	if err := p.Save(*dstWidth, *dstHeight, *dstName); err != nil {
-		log.Fatalf("error saving figure: %s", err)
+// labeledBenchmarks returns the named benchmarks from each set of
+// benchmarks. Each is labeled by the corresponding file label, along
+// with the benchmark name when plotting multiple benchmarks.
+func labeledBenchmarks(benchSets [][]benchparse.Benchmark, fileNames, fileLbls []string, benchNames []string) ([]plot.LabeledBenchmark, error) {
+	labeledBenches := make([]plot.LabeledBenchmark, 0, len(benchSets)*len(benchNames))
+	for i, benches := range benchSets {
+		fileLbl := fileLbls[i]
+		for _, benchName := range benchNames {
+			bench, err := findBenchmark(benches, benchName)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", fileNames[i], err)
+			}
+
+			label := fileLbl
+			if len(benchNames) > 1 {
+				if label != "" {
+					label += ","
+				}
+				label += bench.Name
+			}
+			labeledBenches = append(labeledBenches, plot.LabeledBenchmark{Label: label, Benchmark: bench})
+		}
 	}
+	return labeledBenches, nil
 }
 
-func findBenchmark(benches []benchparse.Benchmark, benchName string) (benchparse.Benchmark, error) {
-	for i := range benches {
-		if benches[i].Name == benchName {
-			return benches[i], nil
+// parseFile parses the benchmarks in the named file, or stdin
+// if the name is "-", which are in the specified format. If the
+// format is input.AutoFormat, files with the ".csv" extension are
+// read as CSV.
+func parseFile(name string, format input.Format, columns input.Columns) ([]benchparse.Benchmark, error) {
+	if name == "-" {
+		benches, err := input.ParseFormat(os.Stdin, format, columns)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing input: %w", err)
 		}
+		return benches, nil
+	}
+
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("error opening '%s': %w", name, err)
+	}
+	defer f.Close()
+
+	if format == input.AutoFormat {
+		format = input.FormatOf(name)
+	}
+	benches, err := input.ParseFormat(f, format, columns)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing '%s': %w", name, err)
 	}
-	return benchparse.Benchmark{}, fmt.Errorf("no benches found with name: %s", benchName)
+	return benches, nil
 }
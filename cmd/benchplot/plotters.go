@@ -0,0 +1,140 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ShawnROGrady/benchplot/gonum"
+	"github.com/ShawnROGrady/benchplot/htmlplot"
+	"github.com/ShawnROGrady/benchplot/plot/plotter"
+	"github.com/ShawnROGrady/benchplot/term"
+)
+
+// figurePlotter is a Plotter which draws a figure that can be saved.
+type figurePlotter interface {
+	plotter.Plotter
+	Save(dstWidth, dstHeight float64, dstName string) error
+}
+
+// newFigurePlotter returns the plotter used to draw the figure,
+// based on the extension of the output file name.
+func newFigurePlotter(dstName string, topLegend, leftLegend bool) figurePlotter {
+	if dstName == stdoutDst {
+		return termPlotter{Plotter: &term.Plotter{Color: useColor(os.Stdout)}, out: os.Stdout}
+	}
+	if strings.EqualFold(filepath.Ext(dstName), ".html") {
+		return &htmlplot.Plotter{}
+	}
+	return &gonum.Plotter{
+		TopLegend:  topLegend,
+		LeftLegend: leftLegend,
+	}
+}
+
+// termPlotter draws the figure in the terminal when saved, with the
+// width and height interpreted as columns and rows.
+type termPlotter struct {
+	*term.Plotter
+	out io.Writer
+}
+
+func (t termPlotter) Save(dstWidth, dstHeight float64, dstName string) error {
+	return t.Write(t.out, int(dstWidth), int(dstHeight))
+}
+
+// useColor reports whether ANSI colors should be written to f, which
+// is only the case for terminals when NO_COLOR is not set.
+func useColor(f *os.File) bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// multiPlotter passes every plot to each of its plotters, allowing
+// the same data to be written to multiple outputs.
+type multiPlotter []plotter.Plotter
+
+func (m multiPlotter) PlotScatter(data map[string]plotter.NumericData, title, xLabel, yLabel string, includeLegend bool) error {
+	for _, p := range m {
+		if err := p.PlotScatter(data, title, xLabel, yLabel, includeLegend); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m multiPlotter) PlotLine(data map[string]plotter.NumericData, title, xLabel, yLabel string, includeLegend bool) error {
+	for _, p := range m {
+		if err := p.PlotLine(data, title, xLabel, yLabel, includeLegend); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m multiPlotter) PlotBar(data map[string]plotter.CategoricalData, title, xLabel, yLabel string, includeLegend bool) error {
+	for _, p := range m {
+		if err := p.PlotBar(data, title, xLabel, yLabel, includeLegend); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m multiPlotter) PlotErrorBars(data map[string]plotter.ErrorData, title, xLabel, yLabel string, includeLegend bool) error {
+	for _, p := range m {
+		if err := p.PlotErrorBars(data, title, xLabel, yLabel, includeLegend); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m multiPlotter) PlotBox(data map[string]plotter.DistributionData, title, xLabel, yLabel string, includeLegend bool) error {
+	for _, p := range m {
+		if err := p.PlotBox(data, title, xLabel, yLabel, includeLegend); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m multiPlotter) PlotSignificance(data map[string]plotter.SignificanceData, alpha float64, title, xLabel, yLabel string) error {
+	for _, p := range m {
+		if err := p.PlotSignificance(data, alpha, title, xLabel, yLabel); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m multiPlotter) PlotOutliers(data map[string]plotter.NumericData, title, xLabel, yLabel string) error {
+	for _, p := range m {
+		if err := p.PlotOutliers(data, title, xLabel, yLabel); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m multiPlotter) SetScales(xScale, yScale plotter.Scale) error {
+	for _, p := range m {
+		if err := p.SetScales(xScale, yScale); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m multiPlotter) SetSubtitle(subtitle string) error {
+	for _, p := range m {
+		if err := p.SetSubtitle(subtitle); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ShawnROGrady/benchparse"
+)
+
+// maxSuggestions is the maximum number of benchmark names suggested
+// when no benchmarks match the requested pattern.
+const maxSuggestions = 3
+
+// maxSuggestionDist returns the maximum edit distance between
+// the pattern and a suggested name.
+func maxSuggestionDist(pattern string) int {
+	if d := len(pattern) / 6; d > 2 {
+		return d
+	}
+	return 2
+}
+
+// matchBenchNames returns the names of the benchmarks matching the
+// pattern. The pattern is either the exact name of a benchmark or a
+// regular expression matched against the names of top-level benchmarks.
+// Unlike 'go test -bench' the pattern is not split into levels at each
+// '/', since sub-benchmarks are selected with -filter-by instead.
+func matchBenchNames(names []string, pattern string) ([]string, error) {
+	if pattern == allBenches {
+		return names, nil
+	}
+	for _, name := range names {
+		if name == pattern {
+			return []string{name}, nil
+		}
+	}
+
+	expr, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid benchmark pattern: %w", err)
+	}
+
+	matches := []string{}
+	for _, name := range names {
+		if expr.MatchString(name) {
+			matches = append(matches, name)
+		}
+	}
+	if len(matches) == 0 {
+		if suggestions := suggestBenchNames(names, pattern); len(suggestions) != 0 {
+			return nil, fmt.Errorf("no benches found matching: %s (did you mean %s?)", pattern, strings.Join(suggestions, ", "))
+		}
+		return nil, fmt.Errorf("no benches found matching: %s", pattern)
+	}
+	return matches, nil
+}
+
+// suggestBenchNames returns the names closest to the pattern.
+func suggestBenchNames(names []string, pattern string) []string {
+	type candidate struct {
+		name string
+		dist int
+	}
+
+	var (
+		lowerPattern = strings.ToLower(pattern)
+		candidates   = []candidate{}
+	)
+	for _, name := range names {
+		lowerName := strings.ToLower(name)
+		if strings.Contains(lowerName, lowerPattern) || strings.Contains(lowerPattern, lowerName) {
+			candidates = append(candidates, candidate{name: name, dist: 0})
+			continue
+		}
+
+		dist := editDistance(lowerName, lowerPattern)
+		if dist <= maxSuggestionDist(pattern) {
+			candidates = append(candidates, candidate{name: name, dist: dist})
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].dist != candidates[j].dist {
+			return candidates[i].dist < candidates[j].dist
+		}
+		return candidates[i].name < candidates[j].name
+	})
+
+	suggestions := []string{}
+	for i := 0; i < len(candidates) && i < maxSuggestions; i++ {
+		suggestions = append(suggestions, candidates[i].name)
+	}
+	return suggestions
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = minInt(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+// minInt returns the smallest of the values.
+func minInt(vals ...int) int {
+	m := vals[0]
+	for _, v := range vals[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
+
+// allBenchNames returns the sorted names of every benchmark in the sets.
+func allBenchNames(benchSets [][]benchparse.Benchmark) []string {
+	var (
+		names = []string{}
+		seen  = map[string]bool{}
+	)
+	for _, benches := range benchSets {
+		for _, bench := range benches {
+			if !seen[bench.Name] {
+				seen[bench.Name] = true
+				names = append(names, bench.Name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func findBenchmark(benches []benchparse.Benchmark, benchName string) (benchparse.Benchmark, error) {
+	for i := range benches {
+		if benches[i].Name == benchName {
+			return benches[i], nil
+		}
+	}
+	return benchparse.Benchmark{}, fmt.Errorf("no benches found with name: %s", benchName)
+}
+
+// figureBenchNames returns the names of the benchmarks to plot on each
+// figure. If batch is set every benchmark matching the single pattern is
+// plotted to a separate figure, otherwise the benchmarks matching every
+// pattern are plotted to the same figure.
+func figureBenchNames(allNames, patterns []string, batch bool) ([][]string, error) {
+	if batch {
+		names, err := matchBenchNames(allNames, patterns[0])
+		if err != nil {
+			return nil, err
+		}
+		figures := make([][]string, len(names))
+		for i, name := range names {
+			figures[i] = []string{name}
+		}
+		return figures, nil
+	}
+
+	var (
+		names = []string{}
+		seen  = map[string]bool{}
+	)
+	for _, pattern := range patterns {
+		matched, err := matchBenchNames(allNames, pattern)
+		if err != nil {
+			return nil, err
+		}
+		if len(patterns) == 1 && len(matched) > 1 {
+			return nil, fmt.Errorf("multiple benches found matching: %s %q (use a more specific pattern, an output file name template to plot each, or repeat -bench to plot them together)", pattern, matched)
+		}
+		for _, name := range matched {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	return [][]string{names}, nil
+}
+
+// batchMode reports whether every benchmark matching the single
+// pattern is plotted to a separate figure, which is the case when the
+// pattern is "all" or the output file name is a template. Benchmarks
+// matching multiple patterns are always plotted to the same figure.
+func batchMode(patterns []string, dstName string) bool {
+	if len(patterns) != 1 {
+		return false
+	}
+	return patterns[0] == allBenches || strings.Contains(dstName, "{{")
+}
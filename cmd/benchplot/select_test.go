@@ -0,0 +1,243 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+var sampleBenchNames = []string{"BenchmarkGet", "BenchmarkPut", "BenchmarkSort"}
+
+var batchModeTests = map[string]struct {
+	patterns      []string
+	dstName       string
+	expectedBatch bool
+}{
+	"name": {
+		patterns: []string{"BenchmarkGet"},
+		dstName:  "get.png",
+	},
+	"name,no_dst": {
+		patterns: []string{"BenchmarkGet"},
+	},
+	"all": {
+		patterns:      []string{allBenches},
+		expectedBatch: true,
+	},
+	"template": {
+		patterns:      []string{"Benchmark(Get|Put)"},
+		dstName:       "{{.Bench}}.svg",
+		expectedBatch: true,
+	},
+	"multiple_patterns,template": {
+		patterns: []string{"BenchmarkGet", "BenchmarkPut"},
+		dstName:  "{{.X}}.png",
+	},
+	"multiple_patterns,all": {
+		patterns: []string{allBenches, "BenchmarkPut"},
+	},
+}
+
+func TestBatchMode(t *testing.T) {
+	for testName, testCase := range batchModeTests {
+		t.Run(testName, func(t *testing.T) {
+			if batch := batchMode(testCase.patterns, testCase.dstName); batch != testCase.expectedBatch {
+				t.Errorf("unexpected batch mode (expected=%t, actual=%t)", testCase.expectedBatch, batch)
+			}
+		})
+	}
+}
+
+var figureBenchNamesTests = map[string]struct {
+	patterns        []string
+	batch           bool
+	expectedFigures [][]string
+	expectErr       bool
+}{
+	"single": {
+		patterns:        []string{"BenchmarkGet"},
+		expectedFigures: [][]string{{"BenchmarkGet"}},
+	},
+	"single,multiple_matches": {
+		patterns:  []string{"Benchmark(Get|Put)"},
+		expectErr: true,
+	},
+	"batch,all": {
+		patterns:        []string{allBenches},
+		batch:           true,
+		expectedFigures: [][]string{{"BenchmarkGet"}, {"BenchmarkPut"}, {"BenchmarkSort"}},
+	},
+	"batch,pattern": {
+		patterns:        []string{"Benchmark(Get|Put)"},
+		batch:           true,
+		expectedFigures: [][]string{{"BenchmarkGet"}, {"BenchmarkPut"}},
+	},
+	"batch,no_match": {
+		patterns:  []string{"BenchmarkDelete"},
+		batch:     true,
+		expectErr: true,
+	},
+	"overlay": {
+		patterns:        []string{"BenchmarkSort", "Benchmark(Get|Put)"},
+		expectedFigures: [][]string{{"BenchmarkSort", "BenchmarkGet", "BenchmarkPut"}},
+	},
+	"overlay,duplicates": {
+		patterns:        []string{"BenchmarkGet", "Benchmark(Get|Put)"},
+		expectedFigures: [][]string{{"BenchmarkGet", "BenchmarkPut"}},
+	},
+	"overlay,no_match": {
+		patterns:  []string{"BenchmarkGet", "BenchmarkDelete"},
+		expectErr: true,
+	},
+}
+
+func TestFigureBenchNames(t *testing.T) {
+	for testName, testCase := range figureBenchNamesTests {
+		t.Run(testName, func(t *testing.T) {
+			figures, err := figureBenchNames(sampleBenchNames, testCase.patterns, testCase.batch)
+			if err != nil {
+				if !testCase.expectErr {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			if testCase.expectErr {
+				t.Fatalf("unexpectedly no error")
+			}
+
+			if !reflect.DeepEqual(figures, testCase.expectedFigures) {
+				t.Errorf("unexpected figures (expected=%q, actual=%q)", testCase.expectedFigures, figures)
+			}
+		})
+	}
+}
+
+var matchBenchNamesTests = map[string]struct {
+	pattern         string
+	expectedMatches []string
+	expectedErr     string
+}{
+	"all": {
+		pattern:         allBenches,
+		expectedMatches: sampleBenchNames,
+	},
+	"exact": {
+		pattern:         "BenchmarkGet",
+		expectedMatches: []string{"BenchmarkGet"},
+	},
+	"exact,regexp_metacharacters": {
+		pattern:         "BenchmarkSort",
+		expectedMatches: []string{"BenchmarkSort"},
+	},
+	"regexp": {
+		pattern:         "Get|Put",
+		expectedMatches: []string{"BenchmarkGet", "BenchmarkPut"},
+	},
+	"regexp,anchored": {
+		pattern:         "^BenchmarkS",
+		expectedMatches: []string{"BenchmarkSort"},
+	},
+	"invalid_regexp": {
+		pattern:     "Benchmark(Get",
+		expectedErr: "invalid benchmark pattern: error parsing regexp: missing closing ): `Benchmark(Get`",
+	},
+	"no_match,suggestion": {
+		pattern:     "BenchmarkGte",
+		expectedErr: "no benches found matching: BenchmarkGte (did you mean BenchmarkGet?)",
+	},
+	"no_match,no_suggestion": {
+		pattern:     "Delete",
+		expectedErr: "no benches found matching: Delete",
+	},
+}
+
+func TestMatchBenchNames(t *testing.T) {
+	for testName, testCase := range matchBenchNamesTests {
+		t.Run(testName, func(t *testing.T) {
+			matches, err := matchBenchNames(sampleBenchNames, testCase.pattern)
+			if err != nil {
+				if err.Error() != testCase.expectedErr {
+					t.Errorf("unexpected error (expected=%q, actual=%q)", testCase.expectedErr, err)
+				}
+				return
+			}
+			if testCase.expectedErr != "" {
+				t.Fatalf("unexpectedly no error")
+			}
+
+			if !reflect.DeepEqual(matches, testCase.expectedMatches) {
+				t.Errorf("unexpected matches (expected=%q, actual=%q)", testCase.expectedMatches, matches)
+			}
+		})
+	}
+}
+
+var suggestBenchNamesTests = map[string]struct {
+	names               []string
+	pattern             string
+	expectedSuggestions []string
+}{
+	"case_insensitive_substring": {
+		names:               sampleBenchNames,
+		pattern:             "sort",
+		expectedSuggestions: []string{"BenchmarkSort"},
+	},
+	"typo": {
+		names:               sampleBenchNames,
+		pattern:             "BenchmarkPtu",
+		expectedSuggestions: []string{"BenchmarkPut"},
+	},
+	"ordered_by_distance": {
+		names:               []string{"BenchmarkGetAll", "BenchmarkGot", "BenchmarkGet"},
+		pattern:             "BenchmarkGxt",
+		expectedSuggestions: []string{"BenchmarkGet", "BenchmarkGot"},
+	},
+	"at_most_max": {
+		names:               []string{"BenchmarkA", "BenchmarkB", "BenchmarkC", "BenchmarkD"},
+		pattern:             "Benchmark",
+		expectedSuggestions: []string{"BenchmarkA", "BenchmarkB", "BenchmarkC"},
+	},
+	"too_far": {
+		names:               sampleBenchNames,
+		pattern:             "Delete",
+		expectedSuggestions: []string{},
+	},
+}
+
+func TestSuggestBenchNames(t *testing.T) {
+	for testName, testCase := range suggestBenchNamesTests {
+		t.Run(testName, func(t *testing.T) {
+			suggestions := suggestBenchNames(testCase.names, testCase.pattern)
+			if !reflect.DeepEqual(suggestions, testCase.expectedSuggestions) {
+				t.Errorf("unexpected suggestions (expected=%q, actual=%q)", testCase.expectedSuggestions, suggestions)
+			}
+		})
+	}
+}
+
+var editDistanceTests = map[string]struct {
+	a, b         string
+	expectedDist int
+}{
+	"equal":        {a: "sort", b: "sort", expectedDist: 0},
+	"empty":        {a: "", b: "put", expectedDist: 3},
+	"substitution": {a: "get", b: "got", expectedDist: 1},
+	"insertion":    {a: "get", b: "gets", expectedDist: 1},
+	"deletion":     {a: "sort", b: "srt", expectedDist: 1},
+	"transposition": {
+		a: "put", b: "ptu", expectedDist: 2,
+	},
+	"kitten": {a: "kitten", b: "sitting", expectedDist: 3},
+}
+
+func TestEditDistance(t *testing.T) {
+	for testName, testCase := range editDistanceTests {
+		t.Run(testName, func(t *testing.T) {
+			if dist := editDistance(testCase.a, testCase.b); dist != testCase.expectedDist {
+				t.Errorf("unexpected distance (expected=%d, actual=%d)", testCase.expectedDist, dist)
+			}
+			if dist := editDistance(testCase.b, testCase.a); dist != testCase.expectedDist {
+				t.Errorf("unexpected reversed distance (expected=%d, actual=%d)", testCase.expectedDist, dist)
+			}
+		})
+	}
+}
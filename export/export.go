@@ -0,0 +1,337 @@
+// Package export contains a Plotter implementation which records
+// the plotted data so it can be written as CSV or JSON.
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ShawnROGrady/benchplot/plot/plotter"
+)
+
+// Format is a format the plotted data can be written in.
+type Format string
+
+// The available formats.
+const (
+	CSVFormat  Format = "csv"
+	JSONFormat Format = "json"
+)
+
+// The aggregations used to compute the exported y values. Lines may
+// also state their own aggregation (e.g. "median").
+const (
+	NoAggregation   = "none"
+	MeanAggregation = "mean"
+)
+
+// The kinds of plot the exported data was computed for.
+const (
+	scatterPlot  = "scatter"
+	linePlot     = "line"
+	barPlot      = "bar"
+	errorBarPlot = "errbar"
+	boxPlot      = "box"
+	signifPlot   = "significance"
+	outlierPlot  = "outlier"
+)
+
+// csvHeader is the header row of the CSV output.
+var csvHeader = []string{"plot", "group", "x", "y", "aggregation", "y_err_low", "y_err_high", "p_value"}
+
+// Row is a single plotted point.
+type Row struct {
+	Plot        string   `json:"plot"`
+	Group       string   `json:"group"`
+	X           string   `json:"x"`
+	Y           float64  `json:"y"`
+	Aggregation string   `json:"aggregation"`
+	YErrLow     *float64 `json:"y_err_low,omitempty"`
+	YErrHigh    *float64 `json:"y_err_high,omitempty"`
+	PValue      *float64 `json:"p_value,omitempty"`
+}
+
+// Plotter records the data of each plot to implement Plotter.
+type Plotter struct {
+	Title    string `json:"title"`
+	Subtitle string `json:"subtitle,omitempty"`
+	XLabel   string `json:"x_label"`
+	YLabel   string `json:"y_label"`
+	Rows     []Row  `json:"rows"`
+}
+
+// FormatOf returns the format corresponding to the extension of the
+// named file, reporting whether the extension is a known format.
+func FormatOf(name string) (Format, bool) {
+	switch f := Format(strings.ToLower(strings.TrimPrefix(filepath.Ext(name), "."))); f {
+	case CSVFormat, JSONFormat:
+		return f, true
+	default:
+		return "", false
+	}
+}
+
+// PlotScatter records every point of the specified data.
+func (e *Plotter) PlotScatter(data map[string]plotter.NumericData, title, xLabel, yLabel string, includeLegend bool) error {
+	e.setLabels(title, xLabel, yLabel)
+	// use sorted keys for consistent iteration order
+	groupNames := make([]string, len(data))
+	j := 0
+	for k := range data {
+		groupNames[j] = k
+		j++
+	}
+	sort.Strings(groupNames)
+
+	for _, group := range groupNames {
+		d := data[group]
+		for i := range d.X {
+			e.Rows = append(e.Rows, Row{Plot: scatterPlot, Group: group, X: formatFloat(d.X[i]), Y: d.Y[i], Aggregation: NoAggregation})
+		}
+	}
+	return nil
+}
+
+// PlotLine records the y value at each x of the specified data, along
+// with the aggregation the data states (e.g. none for fitted curves).
+func (e *Plotter) PlotLine(data map[string]plotter.NumericData, title, xLabel, yLabel string, includeLegend bool) error {
+	e.setLabels(title, xLabel, yLabel)
+	// use sorted keys for consistent iteration order
+	groupNames := make([]string, len(data))
+	j := 0
+	for k := range data {
+		groupNames[j] = k
+		j++
+	}
+	sort.Strings(groupNames)
+
+	for _, group := range groupNames {
+		d := data[group]
+		agg := d.Aggregation
+		if agg == "" {
+			agg = NoAggregation
+		}
+		for i := range d.X {
+			e.Rows = append(e.Rows, Row{Plot: linePlot, Group: group, X: formatFloat(d.X[i]), Y: d.Y[i], Aggregation: agg})
+		}
+	}
+	return nil
+}
+
+// PlotBar records the average y value of each category of the
+// specified data.
+func (e *Plotter) PlotBar(data map[string]plotter.CategoricalData, title, xLabel, yLabel string, includeLegend bool) error {
+	e.setLabels(title, xLabel, yLabel)
+	// use sorted keys for consistent iteration order
+	groupNames := make([]string, len(data))
+	j := 0
+	for k := range data {
+		groupNames[j] = k
+		j++
+	}
+	sort.Strings(groupNames)
+
+	for _, group := range groupNames {
+		d := data[group]
+		for i := range d.X {
+			e.Rows = append(e.Rows, Row{Plot: barPlot, Group: group, X: d.X[i], Y: d.Y[i], Aggregation: MeanAggregation})
+		}
+	}
+	return nil
+}
+
+// PlotErrorBars records the average y value at each x of the specified
+// data along with its error.
+func (e *Plotter) PlotErrorBars(data map[string]plotter.ErrorData, title, xLabel, yLabel string, includeLegend bool) error {
+	e.setLabels(title, xLabel, yLabel)
+	// use sorted keys for consistent iteration order
+	groupNames := make([]string, len(data))
+	j := 0
+	for k := range data {
+		groupNames[j] = k
+		j++
+	}
+	sort.Strings(groupNames)
+
+	for _, group := range groupNames {
+		d := data[group]
+		for i := range d.X {
+			low, high := d.YErrLow[i], d.YErrHigh[i]
+			e.Rows = append(e.Rows, Row{Plot: errorBarPlot, Group: group, X: formatFloat(d.X[i]), Y: d.Y[i], Aggregation: MeanAggregation, YErrLow: &low, YErrHigh: &high})
+		}
+	}
+	return nil
+}
+
+// PlotBox records every y value at each x of the specified data.
+func (e *Plotter) PlotBox(data map[string]plotter.DistributionData, title, xLabel, yLabel string, includeLegend bool) error {
+	e.setLabels(title, xLabel, yLabel)
+	// use sorted keys for consistent iteration order
+	groupNames := make([]string, len(data))
+	j := 0
+	for k := range data {
+		groupNames[j] = k
+		j++
+	}
+	sort.Strings(groupNames)
+
+	for _, group := range groupNames {
+		d := data[group]
+		for i := range d.X {
+			x := formatFloat(d.X[i])
+			if len(d.Labels) != 0 {
+				x = d.Labels[i]
+			}
+			for _, y := range d.Y[i] {
+				e.Rows = append(e.Rows, Row{Plot: boxPlot, Group: group, X: x, Y: y, Aggregation: NoAggregation})
+			}
+		}
+	}
+	return nil
+}
+
+// PlotSignificance records the average y value at each x of the
+// specified data along with the p-value of its difference from the
+// baseline. The p-value is omitted if the test could not be performed.
+func (e *Plotter) PlotSignificance(data map[string]plotter.SignificanceData, alpha float64, title, xLabel, yLabel string) error {
+	e.setLabels(title, xLabel, yLabel)
+	// use sorted keys for consistent iteration order
+	groupNames := make([]string, len(data))
+	j := 0
+	for k := range data {
+		groupNames[j] = k
+		j++
+	}
+	sort.Strings(groupNames)
+
+	for _, group := range groupNames {
+		d := data[group]
+		for i := range d.X {
+			row := Row{Plot: signifPlot, Group: group, X: formatFloat(d.X[i]), Y: d.Y[i], Aggregation: MeanAggregation}
+			if p := d.P[i]; !math.IsNaN(p) {
+				row.PValue = &p
+			}
+			e.Rows = append(e.Rows, row)
+		}
+	}
+	return nil
+}
+
+// PlotOutliers records each y value discarded as an outlier.
+func (e *Plotter) PlotOutliers(data map[string]plotter.NumericData, title, xLabel, yLabel string) error {
+	e.setLabels(title, xLabel, yLabel)
+	// use sorted keys for consistent iteration order
+	groupNames := make([]string, len(data))
+	j := 0
+	for k := range data {
+		groupNames[j] = k
+		j++
+	}
+	sort.Strings(groupNames)
+
+	for _, group := range groupNames {
+		d := data[group]
+		for i := range d.X {
+			e.Rows = append(e.Rows, Row{Plot: outlierPlot, Group: group, X: formatFloat(d.X[i]), Y: d.Y[i], Aggregation: NoAggregation})
+		}
+	}
+	return nil
+}
+
+// SetScales is a no-op since the exported data is not scaled.
+func (e *Plotter) SetScales(xScale, yScale plotter.Scale) error {
+	return nil
+}
+
+// SetSubtitle sets the subtitle, which is only included in JSON output.
+func (e *Plotter) SetSubtitle(subtitle string) error {
+	e.Subtitle = subtitle
+	return nil
+}
+
+// Save writes the recorded data to the named file, in the format
+// corresponding to the file's extension.
+func (e *Plotter) Save(dstName string) error {
+	format, ok := FormatOf(dstName)
+	if !ok {
+		return fmt.Errorf("unsupported data file extension: '%s' (options = %q)", filepath.Ext(dstName), []Format{CSVFormat, JSONFormat})
+	}
+
+	f, err := os.Create(dstName)
+	if err != nil {
+		return fmt.Errorf("error creating '%s': %w", dstName, err)
+	}
+	if err := e.Write(f, format); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Write writes the recorded data to w in the specified format.
+func (e *Plotter) Write(w io.Writer, format Format) error {
+	switch format {
+	case CSVFormat:
+		return e.writeCSV(w)
+	case JSONFormat:
+		return e.writeJSON(w)
+	default:
+		return fmt.Errorf("unknown format: '%s'", format)
+	}
+}
+
+func (e *Plotter) writeCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return fmt.Errorf("error writing csv: %w", err)
+	}
+	for _, row := range e.Rows {
+		record := []string{row.Plot, row.Group, row.X, formatFloat(row.Y), row.Aggregation, "", "", ""}
+		if row.YErrLow != nil {
+			record[5] = formatFloat(*row.YErrLow)
+		}
+		if row.YErrHigh != nil {
+			record[6] = formatFloat(*row.YErrHigh)
+		}
+		if row.PValue != nil {
+			record[7] = formatFloat(*row.PValue)
+		}
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("error writing csv: %w", err)
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("error writing csv: %w", err)
+	}
+	return nil
+}
+
+func (e *Plotter) writeJSON(w io.Writer) error {
+	out := *e
+	if out.Rows == nil {
+		out.Rows = []Row{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		return fmt.Errorf("error writing json: %w", err)
+	}
+	return nil
+}
+
+func (e *Plotter) setLabels(title, xLabel, yLabel string) {
+	e.Title, e.XLabel, e.YLabel = title, xLabel, yLabel
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
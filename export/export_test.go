@@ -0,0 +1,165 @@
+package export
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/ShawnROGrady/benchplot/plot/plotter"
+)
+
+var writeTests = map[string]struct {
+	plot           func(p *Plotter) error
+	format         Format
+	expectedOutput string
+	expectErr      bool
+}{
+	"scatter_and_line,csv": {
+		plot: func(p *Plotter) error {
+			data := map[string]plotter.NumericData{
+				"b": {X: []float64{1}, Y: []float64{3}},
+				"a": {X: []float64{1, 2}, Y: []float64{1.5, 2}},
+			}
+			if err := p.PlotScatter(data, "title", "x", "y", true); err != nil {
+				return err
+			}
+			if err := p.PlotLine(map[string]plotter.NumericData{"a": {X: []float64{1}, Y: []float64{1.25}, Aggregation: "mean"}}, "title", "x", "y", false); err != nil {
+				return err
+			}
+			if err := p.PlotLine(map[string]plotter.NumericData{"b": {X: []float64{1}, Y: []float64{2.75}}}, "title", "x", "y", false); err != nil {
+				return err
+			}
+			return p.PlotLine(map[string]plotter.NumericData{"a (median)": {X: []float64{1}, Y: []float64{1}, Aggregation: "median"}}, "title", "x", "y", true)
+		},
+		format: CSVFormat,
+		expectedOutput: "plot,group,x,y,aggregation,y_err_low,y_err_high,p_value\n" +
+			"scatter,a,1,1.5,none,,,\n" +
+			"scatter,a,2,2,none,,,\n" +
+			"scatter,b,1,3,none,,,\n" +
+			"line,a,1,1.25,mean,,,\n" +
+			"line,b,1,2.75,none,,,\n" +
+			"line,a (median),1,1,median,,,\n",
+	},
+	"bar_and_box,csv": {
+		plot: func(p *Plotter) error {
+			if err := p.PlotBar(map[string]plotter.CategoricalData{"": {X: []string{"x,y"}, Y: []float64{2}}}, "title", "x", "y", true); err != nil {
+				return err
+			}
+			return p.PlotBox(map[string]plotter.DistributionData{"": {X: []float64{0}, Labels: []string{"c"}, Y: [][]float64{{1, 3}}}}, "title", "x", "y", false)
+		},
+		format: CSVFormat,
+		expectedOutput: "plot,group,x,y,aggregation,y_err_low,y_err_high,p_value\n" +
+			"bar,,\"x,y\",2,mean,,,\n" +
+			"box,,c,1,none,,,\n" +
+			"box,,c,3,none,,,\n",
+	},
+	"errbar,json": {
+		plot: func(p *Plotter) error {
+			return p.PlotErrorBars(map[string]plotter.ErrorData{"a": {X: []float64{1}, Y: []float64{2}, YErrLow: []float64{0.5}, YErrHigh: []float64{0.25}}}, "title", "x", "time (ns/op)", true)
+		},
+		format: JSONFormat,
+		expectedOutput: `{
+  "title": "title",
+  "x_label": "x",
+  "y_label": "time (ns/op)",
+  "rows": [
+    {
+      "plot": "errbar",
+      "group": "a",
+      "x": "1",
+      "y": 2,
+      "aggregation": "mean",
+      "y_err_low": 0.5,
+      "y_err_high": 0.25
+    }
+  ]
+}
+`,
+	},
+	"significance,csv": {
+		plot: func(p *Plotter) error {
+			data := map[string]plotter.SignificanceData{
+				"new": {X: []float64{1, 2}, Y: []float64{3, 4}, P: []float64{0.01, math.NaN()}},
+				"old": {X: []float64{}, Y: []float64{}, P: []float64{}},
+			}
+			return p.PlotSignificance(data, 0.05, "title", "x", "y")
+		},
+		format: CSVFormat,
+		expectedOutput: "plot,group,x,y,aggregation,y_err_low,y_err_high,p_value\n" +
+			"significance,new,1,3,mean,,,0.01\n" +
+			"significance,new,2,4,mean,,,\n",
+	},
+	"outliers,csv": {
+		plot: func(p *Plotter) error {
+			return p.PlotOutliers(map[string]plotter.NumericData{"a": {X: []float64{1}, Y: []float64{100}}}, "title", "x", "y")
+		},
+		format: CSVFormat,
+		expectedOutput: "plot,group,x,y,aggregation,y_err_low,y_err_high,p_value\n" +
+			"outlier,a,1,100,none,,,\n",
+	},
+	"empty,json": {
+		plot:   func(p *Plotter) error { return nil },
+		format: JSONFormat,
+		expectedOutput: `{
+  "title": "",
+  "x_label": "",
+  "y_label": "",
+  "rows": []
+}
+`,
+	},
+	"unknown_format": {
+		plot:      func(p *Plotter) error { return nil },
+		format:    Format("xml"),
+		expectErr: true,
+	},
+}
+
+func TestWrite(t *testing.T) {
+	for testName, testCase := range writeTests {
+		t.Run(testName, func(t *testing.T) {
+			p := &Plotter{}
+			if err := testCase.plot(p); err != nil {
+				t.Fatalf("unexpected error plotting: %s", err)
+			}
+
+			var buf bytes.Buffer
+			err := p.Write(&buf, testCase.format)
+			if err != nil {
+				if !testCase.expectErr {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			if testCase.expectErr {
+				t.Fatalf("unexpectedly no error")
+			}
+
+			if buf.String() != testCase.expectedOutput {
+				t.Errorf("unexpected output\nexpected:\n%s\nactual:\n%s", testCase.expectedOutput, buf.String())
+			}
+		})
+	}
+}
+
+func TestFormatOf(t *testing.T) {
+	var tests = map[string]struct {
+		name           string
+		expectedFormat Format
+		expectedOK     bool
+	}{
+		"csv":        {name: "out.csv", expectedFormat: CSVFormat, expectedOK: true},
+		"json_upper": {name: "dir/out.JSON", expectedFormat: JSONFormat, expectedOK: true},
+		"png":        {name: "out.png"},
+		"template":   {name: "{{.Bench}}.csv", expectedFormat: CSVFormat, expectedOK: true},
+	}
+
+	for testName, testCase := range tests {
+		t.Run(testName, func(t *testing.T) {
+			format, ok := FormatOf(testCase.name)
+			if format != testCase.expectedFormat || ok != testCase.expectedOK {
+				t.Errorf("unexpected result for %q (expected = (%q, %t), actual = (%q, %t))", testCase.name, testCase.expectedFormat, testCase.expectedOK, format, ok)
+			}
+		})
+	}
+}
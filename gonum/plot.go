@@ -4,20 +4,34 @@ package gonum
 
 import (
 	"fmt"
+	"image/color"
 	"sort"
+	"strings"
 
 	"github.com/ShawnROGrady/benchplot/plot/plotter"
 	gonumplot "gonum.org/v1/plot"
 	gonumplotter "gonum.org/v1/plot/plotter"
 	"gonum.org/v1/plot/plotutil"
 	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
 )
 
+// barGroupWidth is the total width of the bars drawn for a
+// single category.
+const barGroupWidth = vg.Length(60)
+
+// mutedColor is the color of points which are de-emphasized, such as
+// differences which are not significant or discarded outliers.
+var mutedColor = color.Gray{Y: 160}
+
 // Plotter wraps a gonum/plot.Plot to implement Plotter.
 type Plotter struct {
 	TopLegend  bool
 	LeftLegend bool
 	p          *gonumplot.Plot
+	xScale     plotter.Scale
+	yScale     plotter.Scale
+	subtitle   string
 }
 
 func (g *Plotter) init() error {
@@ -57,6 +71,12 @@ func (g *Plotter) PlotScatter(data map[string]plotter.NumericData, title, xLabel
 	}
 	sort.Strings(groupNames)
 
+	for _, groupName := range groupNames {
+		if err := g.checkScales(data[groupName].X, data[groupName].Y); err != nil {
+			return err
+		}
+	}
+
 	var vs []interface{}
 	if includeLegend {
 		vs = make([]interface{}, len(data)*2)
@@ -93,6 +113,12 @@ func (g *Plotter) PlotLine(data map[string]plotter.NumericData, title, xLabel, y
 	}
 	sort.Strings(groupNames)
 
+	for _, groupName := range groupNames {
+		if err := g.checkScales(data[groupName].X, data[groupName].Y); err != nil {
+			return err
+		}
+	}
+
 	var vs []interface{}
 	if includeLegend {
 		vs = make([]interface{}, len(data)*2)
@@ -111,11 +137,374 @@ func (g *Plotter) PlotLine(data map[string]plotter.NumericData, title, xLabel, y
 	return plotutil.AddLines(g.p, vs...)
 }
 
+// PlotBar creates a bar chart of the specified data, with the bars
+// for each group drawn side-by-side.
+func (g *Plotter) PlotBar(data map[string]plotter.CategoricalData, title, xLabel, yLabel string, includeLegend bool) error {
+	if err := g.init(); err != nil {
+		return err
+	}
+	g.p.Title.Text = title
+	g.p.X.Label.Text = xLabel
+	g.p.Y.Label.Text = yLabel
+
+	// use sorted keys for consistent iteration order
+	groupNames := make([]string, len(data))
+	j := 0
+	for k := range data {
+		groupNames[j] = k
+		j++
+	}
+	sort.Strings(groupNames)
+
+	if g.xScale.IsLog() || g.yScale.IsLog() {
+		return fmt.Errorf("cannot create bar chart with %s x scale and %s y scale", g.xScale, g.yScale)
+	}
+
+	groupXs := make([][]string, len(groupNames))
+	for i, groupName := range groupNames {
+		groupXs[i] = data[groupName].X
+	}
+	categories := plotter.MergeCategories(groupXs)
+	if len(categories) == 0 {
+		return nil
+	}
+
+	categoryPositions := make(map[string]int, len(categories))
+	for i, category := range categories {
+		categoryPositions[category] = i
+	}
+
+	width := barGroupWidth / vg.Length(len(groupNames))
+	for i, groupName := range groupNames {
+		groupData := data[groupName]
+
+		// categories missing from this group are drawn with no height
+		values := make(gonumplotter.Values, len(categories))
+		for k, category := range groupData.X {
+			values[categoryPositions[category]] = groupData.Y[k]
+		}
+
+		bars, err := gonumplotter.NewBarChart(values, width)
+		if err != nil {
+			return fmt.Errorf("error creating bar chart: %w", err)
+		}
+		bars.LineStyle.Width = vg.Length(0)
+		bars.Color = plotutil.Color(i)
+		bars.Offset = (vg.Length(i) - vg.Length(len(groupNames)-1)/2) * width
+
+		g.p.Add(bars)
+		if includeLegend {
+			g.p.Legend.Add(groupName, bars)
+		}
+	}
+	g.p.NominalX(categories...)
+	return nil
+}
+
+// PlotErrorBars creates a line plot of the specified data with
+// error bars at each point.
+func (g *Plotter) PlotErrorBars(data map[string]plotter.ErrorData, title, xLabel, yLabel string, includeLegend bool) error {
+	if err := g.init(); err != nil {
+		return err
+	}
+	g.p.Title.Text = title
+	g.p.X.Label.Text = xLabel
+	g.p.Y.Label.Text = yLabel
+
+	// use sorted keys for consistent iteration order
+	groupNames := make([]string, len(data))
+	j := 0
+	for k := range data {
+		groupNames[j] = k
+		j++
+	}
+	sort.Strings(groupNames)
+
+	for i, groupName := range groupNames {
+		groupData := data[groupName]
+
+		yMins := make([]float64, len(groupData.Y))
+		for k, y := range groupData.Y {
+			yMins[k] = y - groupData.YErrLow[k]
+		}
+		if err := g.checkScales(groupData.X, yMins); err != nil {
+			return err
+		}
+
+		xyErrs := errorDataXYErrors(groupData)
+		line, points, err := gonumplotter.NewLinePoints(xyErrs)
+		if err != nil {
+			return fmt.Errorf("error creating line: %w", err)
+		}
+		line.Color = plotutil.Color(i)
+		line.Dashes = plotutil.Dashes(i)
+		points.Color = plotutil.Color(i)
+		points.Shape = plotutil.Shape(i)
+
+		errBars, err := gonumplotter.NewYErrorBars(xyErrs)
+		if err != nil {
+			return fmt.Errorf("error creating error bars: %w", err)
+		}
+		errBars.Color = plotutil.Color(i)
+
+		g.p.Add(line, points, errBars)
+		if includeLegend {
+			g.p.Legend.Add(groupName, line, points)
+		}
+	}
+	return nil
+}
+
+// PlotBox creates a box plot of the specified data, with the boxes
+// for each group drawn side-by-side.
+func (g *Plotter) PlotBox(data map[string]plotter.DistributionData, title, xLabel, yLabel string, includeLegend bool) error {
+	if err := g.init(); err != nil {
+		return err
+	}
+	g.p.Title.Text = title
+	g.p.X.Label.Text = xLabel
+	g.p.Y.Label.Text = yLabel
+
+	// use sorted keys for consistent iteration order
+	groupNames := make([]string, len(data))
+	j := 0
+	for k := range data {
+		groupNames[j] = k
+		j++
+	}
+	sort.Strings(groupNames)
+
+	var (
+		width = barGroupWidth / vg.Length(len(groupNames))
+		ticks = []gonumplot.Tick{}
+		seen  = map[float64]bool{}
+	)
+	for i, groupName := range groupNames {
+		groupData := data[groupName]
+		if len(groupData.Labels) != 0 && g.xScale.IsLog() {
+			return fmt.Errorf("cannot display categorical x values on %s scale", g.xScale)
+		}
+		for k, x := range groupData.X {
+			if err := g.checkScales([]float64{x}, groupData.Y[k]); err != nil {
+				return err
+			}
+
+			box, err := gonumplotter.NewBoxPlot(width, x, gonumplotter.Values(groupData.Y[k]))
+			if err != nil {
+				return fmt.Errorf("error creating box plot: %w", err)
+			}
+			setBoxStats(box, groupData.Stats[k])
+			box.BoxStyle.Color = plotutil.Color(i)
+			box.MedianStyle.Color = plotutil.Color(i)
+			box.WhiskerStyle.Color = plotutil.Color(i)
+			box.GlyphStyle.Color = plotutil.Color(i)
+			box.Offset = (vg.Length(i) - vg.Length(len(groupNames)-1)/2) * width
+
+			g.p.Add(box)
+			if includeLegend && k == 0 {
+				g.p.Legend.Add(groupName, boxThumbnail{box.BoxStyle})
+			}
+
+			if len(groupData.Labels) != 0 && !seen[x] {
+				seen[x] = true
+				ticks = append(ticks, gonumplot.Tick{Value: x, Label: groupData.Labels[k]})
+			}
+		}
+	}
+	if len(ticks) != 0 {
+		g.p.X.Tick.Marker = gonumplot.ConstantTicks(ticks)
+	}
+	return nil
+}
+
+// setBoxStats replaces the summary gonum computed for the box with
+// the specified one, so it matches the box drawn by other plotters.
+func setBoxStats(box *gonumplotter.BoxPlot, stats plotter.BoxStats) {
+	box.Min, box.Max = stats.Min, stats.Max
+	box.Quartile1, box.Median, box.Quartile3 = stats.Q1, stats.Median, stats.Q3
+	box.AdjLow, box.AdjHigh = stats.Low, stats.High
+	box.Outside = box.Outside[:0]
+	for i, v := range box.Values {
+		if v < stats.Low || v > stats.High {
+			box.Outside = append(box.Outside, i)
+		}
+	}
+}
+
+// PlotSignificance marks each point with a filled circle if its
+// difference from the baseline is significant, and a muted ring
+// otherwise.
+func (g *Plotter) PlotSignificance(data map[string]plotter.SignificanceData, alpha float64, title, xLabel, yLabel string) error {
+	if err := g.init(); err != nil {
+		return err
+	}
+	g.p.Title.Text = title
+	g.p.X.Label.Text = xLabel
+	g.p.Y.Label.Text = yLabel
+
+	// use sorted keys for consistent iteration order
+	groupNames := make([]string, len(data))
+	j := 0
+	for k := range data {
+		groupNames[j] = k
+		j++
+	}
+	sort.Strings(groupNames)
+
+	var (
+		significantStyle = func(i int) draw.GlyphStyle {
+			return draw.GlyphStyle{Color: plotutil.Color(i), Radius: vg.Points(4), Shape: draw.CircleGlyph{}}
+		}
+		mutedStyle     = draw.GlyphStyle{Color: mutedColor, Radius: vg.Points(4), Shape: draw.RingGlyph{}}
+		hasSignificant = false
+		hasMuted       = false
+	)
+	for i, groupName := range groupNames {
+		groupData := data[groupName]
+		if err := g.checkScales(groupData.X, groupData.Y); err != nil {
+			return err
+		}
+
+		var significant, muted gonumplotter.XYs
+		for k := range groupData.X {
+			xy := gonumplotter.XY{X: groupData.X[k], Y: groupData.Y[k]}
+			if groupData.P[k] < alpha {
+				significant = append(significant, xy)
+			} else {
+				muted = append(muted, xy)
+			}
+		}
+
+		for _, points := range []struct {
+			xys   gonumplotter.XYs
+			style draw.GlyphStyle
+		}{{significant, significantStyle(i)}, {muted, mutedStyle}} {
+			if len(points.xys) == 0 {
+				continue
+			}
+			s, err := gonumplotter.NewScatter(points.xys)
+			if err != nil {
+				return fmt.Errorf("error creating scatter: %w", err)
+			}
+			s.GlyphStyle = points.style
+			g.p.Add(s)
+		}
+		hasSignificant = hasSignificant || len(significant) != 0
+		hasMuted = hasMuted || len(muted) != 0
+	}
+
+	// the legend entries describe the markers rather than any group
+	if hasSignificant {
+		style := significantStyle(0)
+		style.Color = color.Black
+		g.p.Legend.Add(fmt.Sprintf("p < %v", alpha), glyphThumbnail{style})
+	}
+	if hasMuted {
+		g.p.Legend.Add("not significant", glyphThumbnail{mutedStyle})
+	}
+	return nil
+}
+
+// PlotOutliers draws points discarded as outliers as gray crosses.
+func (g *Plotter) PlotOutliers(data map[string]plotter.NumericData, title, xLabel, yLabel string) error {
+	if err := g.init(); err != nil {
+		return err
+	}
+	g.p.Title.Text = title
+	g.p.X.Label.Text = xLabel
+	g.p.Y.Label.Text = yLabel
+
+	// use sorted keys for consistent iteration order
+	groupNames := make([]string, len(data))
+	j := 0
+	for k := range data {
+		groupNames[j] = k
+		j++
+	}
+	sort.Strings(groupNames)
+
+	style := draw.GlyphStyle{Color: mutedColor, Radius: vg.Points(3), Shape: draw.CrossGlyph{}}
+	for _, groupName := range groupNames {
+		groupData := data[groupName]
+		if err := g.checkScales(groupData.X, groupData.Y); err != nil {
+			return err
+		}
+		s, err := gonumplotter.NewScatter(numericDataXYs(groupData))
+		if err != nil {
+			return fmt.Errorf("error creating scatter: %w", err)
+		}
+		s.GlyphStyle = style
+		g.p.Add(s)
+	}
+	if len(groupNames) != 0 {
+		g.p.Legend.Add("outliers", glyphThumbnail{style})
+	}
+	return nil
+}
+
+// SetScales sets the scales of the x and y axes.
+func (g *Plotter) SetScales(xScale, yScale plotter.Scale) error {
+	if err := g.init(); err != nil {
+		return err
+	}
+	if err := setAxisScale(&g.p.X, xScale); err != nil {
+		return fmt.Errorf("error setting x scale: %w", err)
+	}
+	if err := setAxisScale(&g.p.Y, yScale); err != nil {
+		return fmt.Errorf("error setting y scale: %w", err)
+	}
+	g.xScale, g.yScale = xScale, yScale
+	return nil
+}
+
+// SetSubtitle sets the line drawn below the title.
+func (g *Plotter) SetSubtitle(subtitle string) error {
+	g.subtitle = subtitle
+	return nil
+}
+
+// wrapSubtitle breaks the subtitle between its "; " separated parts
+// so that each line fits within the width where possible.
+func (g *Plotter) wrapSubtitle(width vg.Length) string {
+	var (
+		lines []string
+		line  string
+	)
+	for _, part := range strings.Split(g.subtitle, "; ") {
+		switch {
+		case line == "":
+			line = part
+		case g.p.Title.Width(line+"; "+part) <= width:
+			line += "; " + part
+		default:
+			lines = append(lines, line)
+			line = part
+		}
+	}
+	return strings.Join(append(lines, line), "\n")
+}
+
+// checkScales verifies the data can be displayed with the
+// scales of each axis.
+func (g *Plotter) checkScales(xs, ys []float64) error {
+	if err := g.xScale.Check("x", xs...); err != nil {
+		return err
+	}
+	return g.yScale.Check("y", ys...)
+}
+
 // Save saves the plot to a file
 func (g *Plotter) Save(dstWidth, dstHeight float64, dstName string) error {
 	if err := g.init(); err != nil {
 		return err
 	}
+	if g.subtitle != "" {
+		// only draw the subtitle while saving so saving again
+		// does not repeat it
+		title := g.p.Title.Text
+		g.p.Title.Text = title + "\n" + g.wrapSubtitle(vg.Length(dstWidth))
+		defer func() { g.p.Title.Text = title }()
+	}
 	return g.p.Save(vg.Length(dstWidth), vg.Length(dstHeight), dstName)
 }
 
@@ -127,3 +516,51 @@ func numericDataXYs(data plotter.NumericData) gonumplotter.XYs {
 	}
 	return xys
 }
+
+// xyErrors implements both the XYer and YErrorer interfaces
+// needed to create error bars.
+type xyErrors struct {
+	gonumplotter.XYs
+	gonumplotter.YErrors
+}
+
+func errorDataXYErrors(data plotter.ErrorData) xyErrors {
+	xyErrs := xyErrors{
+		XYs:     make(gonumplotter.XYs, len(data.X)),
+		YErrors: make(gonumplotter.YErrors, len(data.X)),
+	}
+	for i := 0; i < len(data.X); i++ {
+		xyErrs.XYs[i].X = data.X[i]
+		xyErrs.XYs[i].Y = data.Y[i]
+		xyErrs.YErrors[i].Low = data.YErrLow[i]
+		xyErrs.YErrors[i].High = data.YErrHigh[i]
+	}
+	return xyErrs
+}
+
+// boxThumbnail draws the legend entry for a box plot.
+type boxThumbnail struct {
+	draw.LineStyle
+}
+
+// Thumbnail draws the outline of a box.
+func (b boxThumbnail) Thumbnail(c *draw.Canvas) {
+	pts := []vg.Point{
+		{X: c.Min.X, Y: c.Min.Y},
+		{X: c.Min.X, Y: c.Max.Y},
+		{X: c.Max.X, Y: c.Max.Y},
+		{X: c.Max.X, Y: c.Min.Y},
+		{X: c.Min.X, Y: c.Min.Y},
+	}
+	c.StrokeLines(b.LineStyle, pts)
+}
+
+// glyphThumbnail draws the legend entry for a kind of marker.
+type glyphThumbnail struct {
+	draw.GlyphStyle
+}
+
+// Thumbnail draws the glyph in the center of the canvas.
+func (t glyphThumbnail) Thumbnail(c *draw.Canvas) {
+	c.DrawGlyph(t.GlyphStyle, c.Center())
+}
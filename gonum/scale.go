@@ -0,0 +1,71 @@
+package gonum
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/ShawnROGrady/benchplot/plot/plotter"
+	gonumplot "gonum.org/v1/plot"
+)
+
+// maxLog2Labels is the maximum number of labeled ticks on a log2 axis.
+const maxLog2Labels = 8
+
+// log2Ticks is suitable for the Tick.Marker field of an Axis,
+// it returns tick marks at powers of 2.
+type log2Ticks struct{}
+
+// Ticks returns Ticks in a specified range, or no ticks if the
+// range includes values which cannot be displayed on a log scale.
+func (log2Ticks) Ticks(min, max float64) []gonumplot.Tick {
+	if min <= 0 || max <= 0 {
+		return nil
+	}
+
+	var (
+		minExp = int(math.Floor(math.Log2(min)))
+		maxExp = int(math.Ceil(math.Log2(max)))
+		step   = (maxExp - minExp + maxLog2Labels - 1) / maxLog2Labels
+		ticks  = []gonumplot.Tick{}
+	)
+	if step < 1 {
+		step = 1
+	}
+	for exp := minExp; exp <= maxExp; exp++ {
+		val := math.Pow(2, float64(exp))
+		if (exp-minExp)%step != 0 {
+			ticks = append(ticks, gonumplot.Tick{Value: val})
+			continue
+		}
+		ticks = append(ticks, gonumplot.Tick{Value: val, Label: log2TickLabel(exp)})
+	}
+	return ticks
+}
+
+// log2TickLabel returns the label for the tick at 2^exp.
+func log2TickLabel(exp int) string {
+	if exp < 0 {
+		// fractional powers of 2 have long decimal representations
+		return fmt.Sprintf("2^%d", exp)
+	}
+	return strconv.FormatFloat(math.Pow(2, float64(exp)), 'f', -1, 64)
+}
+
+// setAxisScale configures the axis to use the specified scale.
+func setAxisScale(axis *gonumplot.Axis, scale plotter.Scale) error {
+	switch scale {
+	case plotter.LinearScale, "":
+		axis.Scale = gonumplot.LinearScale{}
+		axis.Tick.Marker = gonumplot.DefaultTicks{}
+	case plotter.Log2Scale:
+		axis.Scale = gonumplot.LogScale{}
+		axis.Tick.Marker = log2Ticks{}
+	case plotter.Log10Scale:
+		axis.Scale = gonumplot.LogScale{}
+		axis.Tick.Marker = gonumplot.LogTicks{}
+	default:
+		return fmt.Errorf("unknown scale: %s", scale)
+	}
+	return nil
+}
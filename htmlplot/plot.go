@@ -0,0 +1,369 @@
+// Package htmlplot contains a Plotter implementation which writes
+// a self-contained interactive HTML page, with the figure drawn as an
+// inline SVG.
+package htmlplot
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/ShawnROGrady/benchplot/plot/plotter"
+)
+
+// The kinds of series which can be drawn.
+const (
+	scatterKind  = "scatter"
+	lineKind     = "line"
+	barKind      = "bar"
+	errorBarKind = "errbar"
+	boxKind      = "box"
+	signifKind   = "significance"
+	outlierKind  = "outlier"
+)
+
+// point is a single drawn value. For box plots box holds the summary
+// of the distribution while y is the median.
+type point struct {
+	x     float64
+	y     float64
+	yLow  float64
+	yHigh float64
+	box   plotter.BoxStats
+	xText string
+
+	// p is the p-value of the difference of a significance point
+	// from its baseline, and significant whether it is below alpha.
+	p           float64
+	significant bool
+}
+
+// series is the data of a single group for a single plot.
+type series struct {
+	kind   string
+	group  string
+	points []point
+}
+
+// Plotter records the data of each plot to implement Plotter.
+// The figure is drawn when saved.
+type Plotter struct {
+	title    string
+	subtitle string
+	xLabel   string
+	yLabel   string
+	xScale   plotter.Scale
+	yScale   plotter.Scale
+	series   []series
+	groups   []string
+
+	// categories are the x tick labels when the x values are the
+	// positions of categories.
+	categories map[float64]string
+}
+
+// PlotScatter creates a scatter plot of the specified data.
+func (h *Plotter) PlotScatter(data map[string]plotter.NumericData, title, xLabel, yLabel string, includeLegend bool) error {
+	h.setLabels(title, xLabel, yLabel)
+	// use sorted keys for consistent iteration order
+	groupNames := make([]string, len(data))
+	j := 0
+	for k := range data {
+		groupNames[j] = k
+		j++
+	}
+	sort.Strings(groupNames)
+
+	for _, groupName := range groupNames {
+		groupData := data[groupName]
+		if err := h.checkScales(groupData.X, groupData.Y); err != nil {
+			return err
+		}
+		h.addSeries(scatterKind, groupName, numericPoints(groupData))
+	}
+	return nil
+}
+
+// PlotLine creates a line plot of the specified data.
+func (h *Plotter) PlotLine(data map[string]plotter.NumericData, title, xLabel, yLabel string, includeLegend bool) error {
+	h.setLabels(title, xLabel, yLabel)
+	// use sorted keys for consistent iteration order
+	groupNames := make([]string, len(data))
+	j := 0
+	for k := range data {
+		groupNames[j] = k
+		j++
+	}
+	sort.Strings(groupNames)
+
+	for _, groupName := range groupNames {
+		groupData := data[groupName]
+		if err := h.checkScales(groupData.X, groupData.Y); err != nil {
+			return err
+		}
+		h.addSeries(lineKind, groupName, numericPoints(groupData))
+	}
+	return nil
+}
+
+// PlotBar creates a bar chart of the specified data, with the bars
+// for each group drawn side-by-side.
+func (h *Plotter) PlotBar(data map[string]plotter.CategoricalData, title, xLabel, yLabel string, includeLegend bool) error {
+	h.setLabels(title, xLabel, yLabel)
+	if h.xScale.IsLog() || h.yScale.IsLog() {
+		return fmt.Errorf("cannot create bar chart with %s x scale and %s y scale", h.xScale, h.yScale)
+	}
+
+	// use sorted keys for consistent iteration order
+	groupNames := make([]string, len(data))
+	j := 0
+	for k := range data {
+		groupNames[j] = k
+		j++
+	}
+	sort.Strings(groupNames)
+
+	groupXs := make([][]string, len(groupNames))
+	for i, groupName := range groupNames {
+		groupXs[i] = data[groupName].X
+	}
+	categories := plotter.MergeCategories(groupXs)
+	categoryPositions := make(map[string]float64, len(categories))
+	for i, category := range categories {
+		categoryPositions[category] = float64(i)
+		h.setCategory(float64(i), category)
+	}
+
+	for _, groupName := range groupNames {
+		groupData := data[groupName]
+		points := make([]point, len(groupData.X))
+		for i, category := range groupData.X {
+			points[i] = point{x: categoryPositions[category], y: groupData.Y[i], xText: category}
+		}
+		h.addSeries(barKind, groupName, points)
+	}
+	return nil
+}
+
+// PlotErrorBars creates a line plot of the specified data with
+// error bars at each point.
+func (h *Plotter) PlotErrorBars(data map[string]plotter.ErrorData, title, xLabel, yLabel string, includeLegend bool) error {
+	h.setLabels(title, xLabel, yLabel)
+	// use sorted keys for consistent iteration order
+	groupNames := make([]string, len(data))
+	j := 0
+	for k := range data {
+		groupNames[j] = k
+		j++
+	}
+	sort.Strings(groupNames)
+
+	for _, groupName := range groupNames {
+		groupData := data[groupName]
+		points := make([]point, len(groupData.X))
+		lows := make([]float64, len(groupData.X))
+		for i := range groupData.X {
+			points[i] = point{
+				x:     groupData.X[i],
+				y:     groupData.Y[i],
+				yLow:  groupData.Y[i] - groupData.YErrLow[i],
+				yHigh: groupData.Y[i] + groupData.YErrHigh[i],
+				xText: formatValue(groupData.X[i]),
+			}
+			lows[i] = points[i].yLow
+		}
+		if err := h.checkScales(groupData.X, lows); err != nil {
+			return err
+		}
+		h.addSeries(errorBarKind, groupName, points)
+	}
+	return nil
+}
+
+// PlotBox creates a box plot of the specified data, with the boxes
+// for each group drawn side-by-side.
+func (h *Plotter) PlotBox(data map[string]plotter.DistributionData, title, xLabel, yLabel string, includeLegend bool) error {
+	h.setLabels(title, xLabel, yLabel)
+	// use sorted keys for consistent iteration order
+	groupNames := make([]string, len(data))
+	j := 0
+	for k := range data {
+		groupNames[j] = k
+		j++
+	}
+	sort.Strings(groupNames)
+
+	for _, groupName := range groupNames {
+		groupData := data[groupName]
+		if len(groupData.Labels) != 0 && h.xScale.IsLog() {
+			return fmt.Errorf("cannot display categorical x values on %s scale", h.xScale)
+		}
+
+		points := make([]point, 0, len(groupData.X))
+		for i, x := range groupData.X {
+			if len(groupData.Y[i]) == 0 {
+				continue
+			}
+			if err := h.checkScales([]float64{x}, groupData.Y[i]); err != nil {
+				return err
+			}
+
+			box := groupData.Stats[i]
+			p := point{x: x, y: box.Median, box: box, xText: formatValue(x)}
+			if len(groupData.Labels) != 0 {
+				p.xText = groupData.Labels[i]
+				h.setCategory(x, groupData.Labels[i])
+			}
+			points = append(points, p)
+		}
+		h.addSeries(boxKind, groupName, points)
+	}
+	return nil
+}
+
+// PlotSignificance marks the points of the specified data, with points
+// that differ significantly from the baseline filled in and the rest
+// drawn hollow in gray.
+func (h *Plotter) PlotSignificance(data map[string]plotter.SignificanceData, alpha float64, title, xLabel, yLabel string) error {
+	h.setLabels(title, xLabel, yLabel)
+	// use sorted keys for consistent iteration order
+	groupNames := make([]string, len(data))
+	j := 0
+	for k := range data {
+		groupNames[j] = k
+		j++
+	}
+	sort.Strings(groupNames)
+
+	for _, groupName := range groupNames {
+		groupData := data[groupName]
+		if err := h.checkScales(groupData.X, groupData.Y); err != nil {
+			return err
+		}
+		points := make([]point, len(groupData.X))
+		for i := range groupData.X {
+			points[i] = point{
+				x:           groupData.X[i],
+				y:           groupData.Y[i],
+				xText:       formatValue(groupData.X[i]),
+				p:           groupData.P[i],
+				significant: groupData.P[i] < alpha,
+			}
+		}
+		h.addSeries(signifKind, groupName, points)
+	}
+	return nil
+}
+
+// PlotOutliers draws points discarded as outliers as gray crosses.
+func (h *Plotter) PlotOutliers(data map[string]plotter.NumericData, title, xLabel, yLabel string) error {
+	h.setLabels(title, xLabel, yLabel)
+	// use sorted keys for consistent iteration order
+	groupNames := make([]string, len(data))
+	j := 0
+	for k := range data {
+		groupNames[j] = k
+		j++
+	}
+	sort.Strings(groupNames)
+
+	for _, groupName := range groupNames {
+		groupData := data[groupName]
+		if err := h.checkScales(groupData.X, groupData.Y); err != nil {
+			return err
+		}
+		h.addSeries(outlierKind, groupName, numericPoints(groupData))
+	}
+	return nil
+}
+
+// SetScales sets the scales of the x and y axes.
+func (h *Plotter) SetScales(xScale, yScale plotter.Scale) error {
+	for _, scale := range []plotter.Scale{xScale, yScale} {
+		switch scale {
+		case plotter.LinearScale, plotter.Log2Scale, plotter.Log10Scale, "":
+		default:
+			return fmt.Errorf("unknown scale: %s", scale)
+		}
+	}
+	h.xScale, h.yScale = xScale, yScale
+	return nil
+}
+
+// SetSubtitle sets the line drawn below the figure.
+func (h *Plotter) SetSubtitle(subtitle string) error {
+	h.subtitle = subtitle
+	return nil
+}
+
+// Save draws the figure and writes it to the named HTML file.
+func (h *Plotter) Save(dstWidth, dstHeight float64, dstName string) error {
+	f, err := os.Create(dstName)
+	if err != nil {
+		return fmt.Errorf("error creating '%s': %w", dstName, err)
+	}
+	if err := h.write(f, dstWidth, dstHeight); err != nil {
+		f.Close()
+		return fmt.Errorf("error writing '%s': %w", dstName, err)
+	}
+	return f.Close()
+}
+
+func (h *Plotter) setLabels(title, xLabel, yLabel string) {
+	h.title, h.xLabel, h.yLabel = title, xLabel, yLabel
+}
+
+func (h *Plotter) setCategory(x float64, label string) {
+	if h.categories == nil {
+		h.categories = map[float64]string{}
+	}
+	h.categories[x] = label
+}
+
+// addSeries records the series, giving each distinct group a
+// consistent color and legend entry across plots.
+func (h *Plotter) addSeries(kind, group string, points []point) {
+	found := false
+	for _, existing := range h.groups {
+		if existing == group {
+			found = true
+			break
+		}
+	}
+	if !found {
+		h.groups = append(h.groups, group)
+	}
+	h.series = append(h.series, series{kind: kind, group: group, points: points})
+}
+
+// checkScales verifies the data can be displayed with the
+// scales of each axis.
+func (h *Plotter) checkScales(xs, ys []float64) error {
+	if err := h.xScale.Check("x", xs...); err != nil {
+		return err
+	}
+	return h.yScale.Check("y", ys...)
+}
+
+func numericPoints(data plotter.NumericData) []point {
+	points := make([]point, len(data.X))
+	for i := range data.X {
+		points[i] = point{x: data.X[i], y: data.Y[i], xText: formatValue(data.X[i])}
+	}
+	return points
+}
+
+func formatValue(v float64) string {
+	return strconv.FormatFloat(v, 'g', 6, 64)
+}
+
+// formatPValue formats the p-value of a significance point, which is
+// NaN if the test could not be performed.
+func formatPValue(p float64) string {
+	if math.IsNaN(p) {
+		return "n/a"
+	}
+	return strconv.FormatFloat(p, 'g', 3, 64)
+}
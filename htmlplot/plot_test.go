@@ -0,0 +1,173 @@
+package htmlplot
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/ShawnROGrady/benchplot/plot/plotter"
+)
+
+var ticksTests = map[string]struct {
+	axis          axis
+	expectedTicks []tick
+}{
+	"linear": {
+		axis:          axis{scale: plotter.LinearScale, min: -0.1, max: 2.6},
+		expectedTicks: []tick{{0, "0"}, {0.5, "0.5"}, {1, "1"}, {1.5, "1.5"}, {2, "2"}, {2.5, "2.5"}},
+	},
+	"linear,fractional_step": {
+		axis:          axis{scale: plotter.LinearScale, min: 0.1, max: 0.4},
+		expectedTicks: []tick{{0.1, "0.1"}, {0.15, "0.15"}, {0.2, "0.2"}, {0.25, "0.25"}, {0.3, "0.3"}, {0.35, "0.35"}, {0.4, "0.4"}},
+	},
+	"log2": {
+		axis:          axis{scale: plotter.Log2Scale, min: 0.4, max: 9},
+		expectedTicks: []tick{{0.5, "2^-1"}, {1, "1"}, {2, "2"}, {4, "4"}, {8, "8"}},
+	},
+	"log2,many": {
+		axis:          axis{scale: plotter.Log2Scale, min: 1, max: 1 << 20},
+		expectedTicks: []tick{{1, "1"}, {8, "8"}, {64, "64"}, {512, "512"}, {4096, "4096"}, {32768, "32768"}, {262144, "262144"}},
+	},
+	"log10": {
+		axis:          axis{scale: plotter.Log10Scale, min: 0.05, max: 200},
+		expectedTicks: []tick{{0.1, "0.1"}, {1, "1"}, {10, "10"}, {100, "100"}},
+	},
+}
+
+func TestTicks(t *testing.T) {
+	for testName, testCase := range ticksTests {
+		t.Run(testName, func(t *testing.T) {
+			ticks := testCase.axis.ticks()
+			if len(ticks) != len(testCase.expectedTicks) {
+				t.Fatalf("unexpected ticks\nexpected:\n%v\nactual:\n%v", testCase.expectedTicks, ticks)
+			}
+			for i, expected := range testCase.expectedTicks {
+				if ticks[i].label != expected.label || math.Abs(ticks[i].value-expected.value) > 1e-9 {
+					t.Errorf("unexpected tick %d (expected = %v, actual = %v)", i, expected, ticks[i])
+				}
+			}
+		})
+	}
+}
+
+var writeTests = map[string]struct {
+	xScale             plotter.Scale
+	yScale             plotter.Scale
+	plot               func(p *Plotter) error
+	expectedContents   []string
+	unexpectedContents []string
+	expectErr          bool
+}{
+	"line": {
+		plot: func(p *Plotter) error {
+			return p.PlotLine(map[string]plotter.NumericData{
+				"impl=a":   {X: []float64{1, 2}, Y: []float64{1.5, 3}},
+				"impl=<b>": {X: []float64{1, 2}, Y: []float64{2, 4}},
+			}, "BenchmarkFoo", "n", "time (ns/op)", true)
+		},
+		expectedContents: []string{
+			"<title>BenchmarkFoo</title>",
+			`<li data-group="0"><span class="swatch" style="background:rgb(241,90,96)"></span>impl=&lt;b&gt;</li>`,
+			`<li data-group="1"><span class="swatch" style="background:rgb(122,195,106)"></span>impl=a</li>`,
+			`data-tip="impl=a` + "\nx: 2\ny: 3\"",
+			`<g class="line" data-group="1"`,
+			">time (ns/op)</text>",
+		},
+		unexpectedContents: []string{`<p class="subtitle">`},
+	},
+	"subtitle": {
+		plot: func(p *Plotter) error {
+			if err := p.SetSubtitle("goos: linux; pkg: <example>"); err != nil {
+				return err
+			}
+			return p.PlotScatter(map[string]plotter.NumericData{"": {X: []float64{1}, Y: []float64{1}}}, "BenchmarkFoo", "n", "y", true)
+		},
+		expectedContents: []string{`<p class="subtitle">goos: linux; pkg: &lt;example&gt;</p>`},
+	},
+	"bar": {
+		plot: func(p *Plotter) error {
+			return p.PlotBar(map[string]plotter.CategoricalData{
+				"": {X: []string{"naive", "fast"}, Y: []float64{2, 1}},
+			}, "BenchmarkFoo", "impl", "y", true)
+		},
+		expectedContents: []string{
+			`<li data-group="0"><span class="swatch" style="background:rgb(241,90,96)"></span>BenchmarkFoo</li>`,
+			">naive</text>",
+			">fast</text>",
+			"data-tip=\"x: fast\ny: 1\"",
+		},
+	},
+	"box": {
+		plot: func(p *Plotter) error {
+			return p.PlotBox(map[string]plotter.DistributionData{
+				"": {
+					X:      []float64{0},
+					Labels: []string{"naive"},
+					Y:      [][]float64{{4, 1, 3, 2, 5, 20}},
+					Stats:  []plotter.BoxStats{{Min: 1, Max: 20, Q1: 2, Median: 3, Q3: 4, Low: 1, High: 5, Outliers: []float64{20}}},
+				},
+			}, "BenchmarkFoo", "impl", "y", true)
+		},
+		expectedContents: []string{
+			">naive</text>",
+			"data-tip=\"x: naive\nmin: 1\nq1: 2\nmedian: 3\nq3: 4\nmax: 20\"",
+			`r="2.5" fill="none"/>`,
+		},
+	},
+	"bar,log_scale": {
+		yScale: plotter.Log10Scale,
+		plot: func(p *Plotter) error {
+			return p.PlotBar(map[string]plotter.CategoricalData{"": {X: []string{"a"}, Y: []float64{1}}}, "", "", "", true)
+		},
+		expectErr: true,
+	},
+	"scatter,log_scale,non_positive": {
+		xScale: plotter.Log2Scale,
+		plot: func(p *Plotter) error {
+			return p.PlotScatter(map[string]plotter.NumericData{"": {X: []float64{0, 1}, Y: []float64{1, 1}}}, "", "", "", true)
+		},
+		expectErr: true,
+	},
+}
+
+func TestWrite(t *testing.T) {
+	for testName, testCase := range writeTests {
+		t.Run(testName, func(t *testing.T) {
+			p := &Plotter{}
+			if err := p.SetScales(testCase.xScale, testCase.yScale); err != nil {
+				t.Fatalf("unexpected error setting scales: %s", err)
+			}
+
+			err := testCase.plot(p)
+			if err != nil {
+				if !testCase.expectErr {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			if testCase.expectErr {
+				t.Fatalf("unexpectedly no error")
+			}
+
+			var buf bytes.Buffer
+			if err := p.write(&buf, 500, 500); err != nil {
+				t.Fatalf("unexpected error writing: %s", err)
+			}
+			page := buf.String()
+			for _, expected := range testCase.expectedContents {
+				if !strings.Contains(page, expected) {
+					t.Errorf("expected page to contain %q\npage:\n%s", expected, page)
+				}
+			}
+			for _, unexpected := range testCase.unexpectedContents {
+				if strings.Contains(page, unexpected) {
+					t.Errorf("expected page not to contain %q\npage:\n%s", unexpected, page)
+				}
+			}
+			if strings.Contains(page, "src=") || strings.Contains(page, "href=") {
+				t.Errorf("expected page to be self-contained")
+			}
+		})
+	}
+}
@@ -0,0 +1,372 @@
+package htmlplot
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/ShawnROGrady/benchplot/plot/plotter"
+)
+
+// barGroupWidth is the maximum total width, in pixels, of the bars
+// or boxes drawn for a single x value.
+const barGroupWidth = 60
+
+// The margins, in pixels, around the plotting area.
+const (
+	marginLeft   = 70
+	marginRight  = 20
+	marginTop    = 40
+	marginBottom = 50
+)
+
+// colors are used for each group in turn, matching the default
+// colors of the gonum plotter.
+var colors = []string{
+	"rgb(241,90,96)",
+	"rgb(122,195,106)",
+	"rgb(90,155,212)",
+	"rgb(250,167,91)",
+	"rgb(158,103,171)",
+	"rgb(206,112,88)",
+	"rgb(215,127,180)",
+}
+
+// write draws the figure and writes the page to w.
+func (h *Plotter) write(w io.Writer, width, height float64) error {
+	var svg strings.Builder
+	h.drawSVG(&svg, width, height)
+
+	var legend strings.Builder
+	for i, group := range h.groups {
+		name := group
+		if name == "" {
+			name = h.title
+		}
+		fmt.Fprintf(&legend, `<li data-group="%d"><span class="swatch" style="background:%s"></span>%s</li>`+"\n", i, color(i), html.EscapeString(name))
+	}
+
+	var subtitle string
+	if h.subtitle != "" {
+		subtitle = fmt.Sprintf(`<p class="subtitle">%s</p>`+"\n", html.EscapeString(h.subtitle))
+	}
+
+	_, err := fmt.Fprintf(w, pageTemplate, html.EscapeString(h.title), svg.String(), legend.String(), subtitle, pageScript)
+	return err
+}
+
+// drawSVG draws the figure as an SVG element.
+func (h *Plotter) drawSVG(svg *strings.Builder, width, height float64) {
+	xAxis, yAxis := h.axes(width, height)
+
+	fmt.Fprintf(svg, `<svg xmlns="http://www.w3.org/2000/svg" width="%g" height="%g" viewBox="0 0 %g %g">`+"\n", width, height, width, height)
+	fmt.Fprintf(svg, `<text class="title" x="%g" y="%d" text-anchor="middle">%s</text>`+"\n", width/2, marginTop/2, html.EscapeString(h.title))
+
+	// axes and ticks
+	fmt.Fprintf(svg, `<line class="axis" x1="%g" y1="%g" x2="%g" y2="%g"/>`+"\n", xAxis.start, yAxis.start, xAxis.end, yAxis.start)
+	fmt.Fprintf(svg, `<line class="axis" x1="%g" y1="%g" x2="%g" y2="%g"/>`+"\n", xAxis.start, yAxis.start, xAxis.start, yAxis.end)
+	for _, t := range h.xTicks(xAxis) {
+		x := xAxis.pos(t.value)
+		fmt.Fprintf(svg, `<line class="tick" x1="%g" y1="%g" x2="%g" y2="%g"/>`+"\n", x, yAxis.start, x, yAxis.start+5)
+		fmt.Fprintf(svg, `<text x="%g" y="%g" text-anchor="middle">%s</text>`+"\n", x, yAxis.start+18, html.EscapeString(t.label))
+	}
+	for _, t := range yAxis.ticks() {
+		y := yAxis.pos(t.value)
+		fmt.Fprintf(svg, `<line class="grid" x1="%g" y1="%g" x2="%g" y2="%g"/>`+"\n", xAxis.start, y, xAxis.end, y)
+		fmt.Fprintf(svg, `<text x="%g" y="%g" text-anchor="end" dominant-baseline="middle">%s</text>`+"\n", xAxis.start-8, y, html.EscapeString(t.label))
+	}
+	fmt.Fprintf(svg, `<text class="label" x="%g" y="%g" text-anchor="middle">%s</text>`+"\n", (xAxis.start+xAxis.end)/2, height-12, html.EscapeString(h.xLabel))
+	fmt.Fprintf(svg, `<text class="label" transform="translate(16,%g) rotate(-90)" text-anchor="middle">%s</text>`+"\n", (yAxis.start+yAxis.end)/2, html.EscapeString(h.yLabel))
+
+	groupWidth := barGroupWidth
+	if len(h.categories) != 0 {
+		groupWidth = int(math.Min(barGroupWidth, 0.8*(xAxis.pos(1)-xAxis.pos(0))))
+	}
+	for _, s := range h.series {
+		var (
+			groupIndex = h.groupIndex(s.group)
+			c          = color(groupIndex)
+			offset     = 0.0
+			itemWidth  = float64(groupWidth)
+		)
+		if s.kind == barKind || s.kind == boxKind {
+			i, n := h.kindGroupIndex(s.kind, s.group)
+			itemWidth = float64(groupWidth) / float64(n)
+			offset = (float64(i) - float64(n-1)/2) * itemWidth
+		}
+
+		fmt.Fprintf(svg, `<g class="%s" data-group="%d" stroke="%s" fill="%s">`+"\n", s.kind, groupIndex, c, c)
+		switch s.kind {
+		case scatterKind:
+			for _, p := range s.points {
+				drawPoint(svg, xAxis.pos(p.x), yAxis.pos(p.y), h.tip(s.group, p.xText, formatValue(p.y)))
+			}
+		case lineKind, errorBarKind:
+			coords := make([]string, len(s.points))
+			for i, p := range s.points {
+				coords[i] = fmt.Sprintf("%g,%g", xAxis.pos(p.x), yAxis.pos(p.y))
+			}
+			fmt.Fprintf(svg, `<polyline fill="none" points="%s"/>`+"\n", strings.Join(coords, " "))
+			for _, p := range s.points {
+				x, y := xAxis.pos(p.x), yAxis.pos(p.y)
+				yText := formatValue(p.y)
+				if s.kind == errorBarKind {
+					low, high := yAxis.pos(p.yLow), yAxis.pos(p.yHigh)
+					fmt.Fprintf(svg, `<path fill="none" d="M%g,%gV%gM%g,%gH%gM%g,%gH%g"/>`+"\n", x, low, high, x-4, low, x+4, x-4, high, x+4)
+					yText = fmt.Sprintf("%s (%s to %s)", yText, formatValue(p.yLow), formatValue(p.yHigh))
+				}
+				drawPoint(svg, x, y, h.tip(s.group, p.xText, yText))
+			}
+		case outlierKind:
+			for _, p := range s.points {
+				x, y := xAxis.pos(p.x), yAxis.pos(p.y)
+				tip := h.tip(s.group, p.xText, formatValue(p.y)) + "\n(outlier)"
+				fmt.Fprintf(svg, `<path class="muted" d="M%g,%gL%g,%gM%g,%gL%g,%g" data-tip="%s"/>`+"\n", x-3, y-3, x+3, y+3, x-3, y+3, x+3, y-3, html.EscapeString(tip))
+			}
+		case signifKind:
+			for _, p := range s.points {
+				var (
+					x, y = xAxis.pos(p.x), yAxis.pos(p.y)
+					tip  = h.tip(s.group, p.xText, formatValue(p.y)) + "\np: " + formatPValue(p.p)
+				)
+				if p.significant {
+					fmt.Fprintf(svg, `<circle cx="%g" cy="%g" r="5" data-tip="%s"/>`+"\n", x, y, html.EscapeString(tip))
+					continue
+				}
+				fmt.Fprintf(svg, `<circle class="muted" cx="%g" cy="%g" r="5" fill="none" data-tip="%s"/>`+"\n", x, y, html.EscapeString(tip))
+			}
+		case barKind:
+			base := yAxis.pos(0)
+			for _, p := range s.points {
+				x, y := xAxis.pos(p.x)+offset-itemWidth/2, yAxis.pos(p.y)
+				top, barHeight := math.Min(y, base), math.Abs(base-y)
+				fmt.Fprintf(svg, `<rect x="%g" y="%g" width="%g" height="%g" stroke="none" data-tip="%s"/>`+"\n", x, top, itemWidth, barHeight, html.EscapeString(h.tip(s.group, p.xText, formatValue(p.y))))
+			}
+		case boxKind:
+			for _, p := range s.points {
+				var (
+					x      = xAxis.pos(p.x) + offset
+					q1, q3 = p.box.Q1, p.box.Q3
+					lo, hi = p.box.Low, p.box.High
+					half   = itemWidth / 2 * 0.8
+				)
+				stats := fmt.Sprintf("min: %s\nq1: %s\nmedian: %s\nq3: %s\nmax: %s", formatValue(p.box.Min), formatValue(q1), formatValue(p.y), formatValue(q3), formatValue(p.box.Max))
+				fmt.Fprintf(svg, `<g data-tip="%s">`, html.EscapeString(h.groupTip(s.group)+"x: "+p.xText+"\n"+stats))
+				fmt.Fprintf(svg, `<path fill="none" d="M%g,%gV%gM%g,%gV%gM%g,%gH%gM%g,%gH%g"/>`, x, yAxis.pos(lo), yAxis.pos(q1), x, yAxis.pos(q3), yAxis.pos(hi), x-half/2, yAxis.pos(lo), x+half/2, x-half/2, yAxis.pos(hi), x+half/2)
+				fmt.Fprintf(svg, `<rect x="%g" y="%g" width="%g" height="%g" fill-opacity="0.2"/>`, x-half, yAxis.pos(q3), 2*half, math.Abs(yAxis.pos(q1)-yAxis.pos(q3)))
+				fmt.Fprintf(svg, `<line x1="%g" y1="%g" x2="%g" y2="%g" stroke-width="2"/>`, x-half, yAxis.pos(p.y), x+half, yAxis.pos(p.y))
+				for _, outlier := range p.box.Outliers {
+					fmt.Fprintf(svg, `<circle cx="%g" cy="%g" r="2.5" fill="none"/>`, x, yAxis.pos(outlier))
+				}
+				svg.WriteString("</g>\n")
+			}
+		}
+		svg.WriteString("</g>\n")
+	}
+	svg.WriteString("</svg>")
+}
+
+// axes returns the x and y axes, covering every drawn value.
+func (h *Plotter) axes(width, height float64) (axis, axis) {
+	var (
+		xs     = []float64{}
+		ys     = []float64{}
+		hasBar = false
+	)
+	for _, s := range h.series {
+		hasBar = hasBar || s.kind == barKind
+		for _, p := range s.points {
+			xs = append(xs, p.x)
+			ys = append(ys, p.y)
+			if s.kind == errorBarKind {
+				ys = append(ys, p.yLow, p.yHigh)
+			}
+			if s.kind == boxKind {
+				ys = append(ys, p.box.Min, p.box.Max)
+			}
+		}
+	}
+	if hasBar {
+		ys = append(ys, 0)
+	}
+
+	xAxis := axis{scale: h.xScale, start: marginLeft, end: width - marginRight}
+	yAxis := axis{scale: h.yScale, start: height - marginBottom, end: marginTop}
+	xAxis.min, xAxis.max = paddedRange(xAxis, xs)
+	yAxis.min, yAxis.max = paddedRange(yAxis, ys)
+	if len(h.categories) != 0 {
+		// leave room for half a category on either side
+		xAxis.min, xAxis.max = minMax(xs)
+		xAxis.min, xAxis.max = xAxis.min-0.5, xAxis.max+0.5
+	}
+	if hasBar && yAxis.min < 0 {
+		// bars should start at the edge of the plotting area
+		if min, _ := minMax(ys); min >= 0 {
+			yAxis.min = 0
+		}
+	}
+	return xAxis, yAxis
+}
+
+// xTicks returns the ticks of the x axis, which are the category
+// labels if the x values are categorical.
+func (h *Plotter) xTicks(xAxis axis) []tick {
+	if len(h.categories) == 0 {
+		return xAxis.ticks()
+	}
+
+	ticks := make([]tick, 0, len(h.categories))
+	for x, label := range h.categories {
+		ticks = append(ticks, tick{value: x, label: label})
+	}
+	sort.Slice(ticks, func(i, j int) bool { return ticks[i].value < ticks[j].value })
+	return ticks
+}
+
+// groupIndex returns the position of the group among every plotted
+// group, used to assign its color.
+func (h *Plotter) groupIndex(group string) int {
+	for i, existing := range h.groups {
+		if existing == group {
+			return i
+		}
+	}
+	return 0
+}
+
+// kindGroupIndex returns the position of the group among the groups
+// plotted with the same kind, along with the number of such groups.
+func (h *Plotter) kindGroupIndex(kind, group string) (int, int) {
+	var (
+		index = 0
+		n     = 0
+	)
+	for _, s := range h.series {
+		if s.kind != kind {
+			continue
+		}
+		if s.group == group {
+			index = n
+		}
+		n++
+	}
+	return index, n
+}
+
+// tip returns the tooltip text of a single point.
+func (h *Plotter) tip(group, x, y string) string {
+	return fmt.Sprintf("%sx: %s\ny: %s", h.groupTip(group), x, y)
+}
+
+func (h *Plotter) groupTip(group string) string {
+	if group == "" {
+		return ""
+	}
+	return group + "\n"
+}
+
+func drawPoint(svg *strings.Builder, x, y float64, tip string) {
+	fmt.Fprintf(svg, `<circle cx="%g" cy="%g" r="3" fill="white" data-tip="%s"/>`+"\n", x, y, html.EscapeString(tip))
+}
+
+// paddedRange returns the range of the values, padded so values are
+// not drawn on the edge of the plotting area.
+func paddedRange(a axis, vals []float64) (float64, float64) {
+	min, max := minMax(vals)
+	lo, hi := a.transform(min), a.transform(max)
+	pad := (hi - lo) * 0.05
+	if pad == 0 {
+		pad = 0.5
+	}
+	return inverse(a.scale, lo-pad), inverse(a.scale, hi+pad)
+}
+
+// inverse returns the data value corresponding to the transformed
+// value.
+func inverse(scale plotter.Scale, v float64) float64 {
+	switch scale {
+	case plotter.Log2Scale:
+		return math.Pow(2, v)
+	case plotter.Log10Scale:
+		return math.Pow(10, v)
+	default:
+		return v
+	}
+}
+
+func minMax(vals []float64) (float64, float64) {
+	if len(vals) == 0 {
+		return 0, 1
+	}
+	min, max := vals[0], vals[0]
+	for _, v := range vals[1:] {
+		min = math.Min(min, v)
+		max = math.Max(max, v)
+	}
+	return min, max
+}
+
+func color(i int) string {
+	return colors[i%len(colors)]
+}
+
+const pageTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>%s</title>
+<style>
+body { font-family: sans-serif; }
+svg text { font-size: 12px; fill: black; stroke: none; }
+svg .title { font-size: 14px; }
+svg .axis, svg .tick { stroke: black; }
+svg .grid { stroke: #e5e5e5; }
+svg .muted { stroke: #a0a0a0; }
+svg [data-tip]:hover { stroke-width: 2; }
+.tooltip { position: absolute; display: none; pointer-events: none; white-space: pre; background: white; border: 1px solid #999; padding: 4px 6px; font-size: 12px; }
+.legend { list-style: none; padding: 0; }
+.legend li { display: inline-block; margin-right: 16px; cursor: pointer; user-select: none; }
+.legend li.off { opacity: 0.4; text-decoration: line-through; }
+.subtitle { color: #555; font-size: 12px; }
+.legend .swatch { display: inline-block; width: 12px; height: 12px; margin-right: 4px; vertical-align: middle; }
+</style>
+</head>
+<body>
+%s
+<ul class="legend">
+%s</ul>
+%s<div class="tooltip" id="tooltip"></div>
+<script>
+%s
+</script>
+</body>
+</html>
+`
+
+// pageScript shows the tooltip of the hovered element and toggles the
+// visibility of a group when its legend entry is clicked.
+const pageScript = `(function() {
+  var tooltip = document.getElementById("tooltip");
+  document.querySelectorAll("[data-tip]").forEach(function(el) {
+    el.addEventListener("mousemove", function(e) {
+      tooltip.textContent = el.getAttribute("data-tip");
+      tooltip.style.display = "block";
+      tooltip.style.left = (e.pageX + 12) + "px";
+      tooltip.style.top = (e.pageY + 12) + "px";
+    });
+    el.addEventListener("mouseleave", function() {
+      tooltip.style.display = "none";
+    });
+  });
+  document.querySelectorAll(".legend li").forEach(function(item) {
+    item.addEventListener("click", function() {
+      var off = item.classList.toggle("off");
+      document.querySelectorAll('svg [data-group="' + item.getAttribute("data-group") + '"]').forEach(function(el) {
+        el.style.display = off ? "none" : "";
+      });
+    });
+  });
+})();`
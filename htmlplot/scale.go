@@ -0,0 +1,136 @@
+package htmlplot
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/ShawnROGrady/benchplot/plot/plotter"
+)
+
+// maxLogLabels is the maximum number of labeled ticks on a log axis.
+const maxLogLabels = 8
+
+// numLinearTicks is the approximate number of ticks on a linear axis.
+const numLinearTicks = 6
+
+// tick is a marked value along an axis.
+type tick struct {
+	value float64
+	label string
+}
+
+// axis maps data values along one dimension of the figure to
+// pixel positions.
+type axis struct {
+	scale      plotter.Scale
+	min, max   float64
+	start, end float64
+}
+
+// transform returns the value in the (possibly logarithmic) space
+// the axis is drawn in.
+func (a axis) transform(v float64) float64 {
+	switch a.scale {
+	case plotter.Log2Scale:
+		return math.Log2(v)
+	case plotter.Log10Scale:
+		return math.Log10(v)
+	default:
+		return v
+	}
+}
+
+// pos returns the pixel position of the value.
+func (a axis) pos(v float64) float64 {
+	lo, hi := a.transform(a.min), a.transform(a.max)
+	if hi == lo {
+		return (a.start + a.end) / 2
+	}
+	return a.start + (a.transform(v)-lo)/(hi-lo)*(a.end-a.start)
+}
+
+// ticks returns the ticks to mark along the axis, within the
+// range of the axis.
+func (a axis) ticks() []tick {
+	var all []tick
+	switch a.scale {
+	case plotter.Log2Scale:
+		all = logTicks(a.min, a.max, 2)
+	case plotter.Log10Scale:
+		all = logTicks(a.min, a.max, 10)
+	default:
+		all = linearTicks(a.min, a.max)
+	}
+
+	ticks := make([]tick, 0, len(all))
+	for _, t := range all {
+		if t.value >= a.min && t.value <= a.max {
+			ticks = append(ticks, t)
+		}
+	}
+	return ticks
+}
+
+// linearTicks returns evenly spaced ticks at round values.
+func linearTicks(min, max float64) []tick {
+	if max == min {
+		return []tick{{value: min, label: formatValue(min)}}
+	}
+
+	step := niceStep((max - min) / numLinearTicks)
+	ticks := []tick{}
+	for v := math.Ceil(min/step) * step; v <= max+step*1e-9; v += step {
+		// avoid accumulated floating point error in the labels
+		v = math.Round(v/step) * step
+		if v == 0 {
+			// avoid labeling negative zero
+			v = 0
+		}
+		ticks = append(ticks, tick{value: v, label: formatValue(v)})
+	}
+	return ticks
+}
+
+// niceStep returns the smallest step of the form {1,2,5}*10^k which
+// is at least the raw step.
+func niceStep(raw float64) float64 {
+	mag := math.Pow(10, math.Floor(math.Log10(raw)))
+	for _, m := range []float64{1, 2, 5} {
+		// allow for floating point error in the raw step
+		if m*mag >= raw*(1-1e-9) {
+			return m * mag
+		}
+	}
+	return 10 * mag
+}
+
+// logTicks returns ticks at powers of the base, labeling at most
+// maxLogLabels of them.
+func logTicks(min, max, base float64) []tick {
+	var (
+		minExp = int(math.Floor(math.Log(min)/math.Log(base) + 1e-9))
+		maxExp = int(math.Ceil(math.Log(max)/math.Log(base) - 1e-9))
+		step   = (maxExp - minExp + maxLogLabels - 1) / maxLogLabels
+		ticks  = []tick{}
+	)
+	if step < 1 {
+		step = 1
+	}
+	for exp := minExp; exp <= maxExp; exp++ {
+		if (exp-minExp)%step != 0 {
+			continue
+		}
+		v := math.Pow(base, float64(exp))
+		label := formatValue(v)
+		if base == 2 {
+			label = strconv.FormatFloat(v, 'f', -1, 64)
+			if exp < 0 {
+				// fractional powers of 2 have long decimal representations
+				label = fmt.Sprintf("2^%d", exp)
+			}
+		}
+		ticks = append(ticks, tick{value: v, label: label})
+	}
+	return ticks
+}
@@ -0,0 +1,129 @@
+package input
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/ShawnROGrady/benchparse"
+)
+
+// Format is a format benchmark results can be read in.
+type Format string
+
+// The available formats.
+const (
+	AutoFormat     Format = "auto"
+	TextFormat     Format = "text"
+	TestJSONFormat Format = "test2json"
+	CSVFormat      Format = "csv"
+	JSONFormat     Format = "json"
+)
+
+// Formats are the available formats.
+var Formats = []Format{AutoFormat, TextFormat, TestJSONFormat, CSVFormat, JSONFormat}
+
+// FormatOf returns the format of the named file based on its extension,
+// which is CSVFormat for ".csv" files and AutoFormat otherwise.
+func FormatOf(name string) Format {
+	if strings.EqualFold(filepath.Ext(name), ".csv") {
+		return CSVFormat
+	}
+	return AutoFormat
+}
+
+// ParseFormat extracts a list of Benchmarks from input in the specified
+// format, using the columns to read tabular input. If the format is
+// AutoFormat, input starting with a JSON array or object is read as
+// tabular JSON unless its first line is a test2json event (e.g. from
+// 'go test -json'), input whose first line is a CSV header with a
+// column with the role 'name' is read as CSV, and any other input as
+// testing.B output.
+func ParseFormat(r io.Reader, format Format, columns Columns) ([]benchparse.Benchmark, error) {
+	if format == AutoFormat {
+		br := bufio.NewReader(r)
+		format = detectFormat(br, columns)
+		r = br
+	}
+
+	switch format {
+	case TextFormat:
+		return ParseBenchmarks(r)
+	case TestJSONFormat:
+		output, err := decodeTestJSON(r)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding test2json events: %w", err)
+		}
+		return ParseBenchmarks(output)
+	case CSVFormat, JSONFormat:
+		return ParseRecords(r, format, columns)
+	default:
+		return nil, fmt.Errorf("unknown input format: '%s' (options = %q)", format, Formats)
+	}
+}
+
+// detectFormat returns the format of the buffered input without
+// consuming any of it, using the columns to recognize a CSV header.
+func detectFormat(br *bufio.Reader, columns Columns) Format {
+	for n := 1; n <= br.Size(); n++ {
+		b, _ := br.Peek(n)
+		if len(b) < n {
+			break
+		}
+		switch b[n-1] {
+		case '[':
+			return JSONFormat
+		case '{':
+			if isTestEvent(br, n-1) {
+				return TestJSONFormat
+			}
+			return JSONFormat
+		}
+		if !unicode.IsSpace(rune(b[n-1])) {
+			if isCSVHeader(br, n-1, columns) {
+				return CSVFormat
+			}
+			break
+		}
+	}
+	return TextFormat
+}
+
+// isCSVHeader reports whether the buffered line starting at the offset
+// is a CSV header naming multiple columns, one of which has the role
+// 'name'. Lines of testing.B output never have such a column.
+func isCSVHeader(br *bufio.Reader, offset int, columns Columns) bool {
+	b, _ := br.Peek(br.Size())
+	b = b[offset:]
+	if i := bytes.IndexByte(b, '\n'); i >= 0 {
+		b = b[:i]
+	}
+	header, err := csv.NewReader(bytes.NewReader(b)).Read()
+	if err != nil || len(header) < 2 {
+		return false
+	}
+	for _, column := range header {
+		if columns.Role(strings.TrimSpace(column)) == NameRole {
+			return true
+		}
+	}
+	return false
+}
+
+// isTestEvent reports whether the buffered line starting at the
+// offset is a test2json event.
+func isTestEvent(br *bufio.Reader, offset int) bool {
+	b, _ := br.Peek(br.Size())
+	b = b[offset:]
+	if i := bytes.IndexByte(b, '\n'); i >= 0 {
+		b = b[:i]
+	}
+	var event testEvent
+	return json.Unmarshal(b, &event) == nil && event.Action != ""
+}
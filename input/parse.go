@@ -0,0 +1,233 @@
+// Package input contains utilities for reading benchmark results
+// to plot.
+package input
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ShawnROGrady/benchparse"
+)
+
+// the units of the outputs measured by benchparse.BenchOutputs
+var standardUnits = map[string]bool{
+	"ns/op":     true,
+	"MB/s":      true,
+	"B/op":      true,
+	"allocs/op": true,
+}
+
+// metadataKeys are the keys of the configuration lines printed by
+// 'go test -bench' before the results (e.g. "goos: linux"), in the
+// order they are printed.
+var metadataKeys = []string{"goos", "goarch", "pkg", "cpu"}
+
+// Outputs are the outputs of a single benchmark run, including
+// any custom metrics reported via testing.B.ReportMetric.
+type Outputs struct {
+	benchparse.BenchOutputs
+	Metrics  map[string]float64 // custom metrics keyed by unit
+	Metadata map[string]string  // the configuration the benchmark was run with (e.g. goos and cpu) keyed by name
+}
+
+// GetMetric returns the value of the custom metric with the
+// specified unit (e.g. 'hits/op').
+// If not measured benchparse.ErrNotMeasured is returned.
+func (o Outputs) GetMetric(unit string) (float64, error) {
+	if v, ok := o.Metrics[unit]; ok {
+		return v, nil
+	}
+	return 0, benchparse.ErrNotMeasured
+}
+
+// MetricUnits returns the units of the custom metrics in
+// sorted order.
+func (o Outputs) MetricUnits() []string {
+	units := make([]string, 0, len(o.Metrics))
+	for unit := range o.Metrics {
+		units = append(units, unit)
+	}
+	sort.Strings(units)
+	return units
+}
+
+// GetMetadata returns the value of the named configuration the
+// benchmark was run with (e.g. 'goarch'), and whether it is known.
+func (o Outputs) GetMetadata(key string) (string, bool) {
+	v, ok := o.Metadata[key]
+	return v, ok
+}
+
+// MetadataKeys returns the names of the known configuration the
+// benchmark was run with, in the order printed by 'go test -bench'.
+func (o Outputs) MetadataKeys() []string {
+	keys := make([]string, 0, len(o.Metadata))
+	for _, key := range metadataKeys {
+		if _, ok := o.Metadata[key]; ok {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// ParseBenchmarks extracts a list of Benchmarks from testing.B output.
+// This is equivalent to benchparse.ParseBenchmarks except the outputs
+// of each result are of type Outputs, and the benchmarks are returned
+// in the order they first appear. The metadata of each result is the
+// most recent value of each configuration line preceding it.
+func ParseBenchmarks(r io.Reader) ([]benchparse.Benchmark, error) {
+	var (
+		scanner  = bufio.NewScanner(r)
+		lines    = []benchLine{}
+		byName   = map[string][]int{}
+		metadata = map[string]string{}
+		buf      strings.Builder
+	)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if key, value, ok := metadataLine(line); ok {
+			// copied since the previous results share the map
+			updated := make(map[string]string, len(metadata)+1)
+			for k, v := range metadata {
+				updated[k] = v
+			}
+			updated[key] = value
+			metadata = updated
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if !isBenchLine(fields) {
+			continue
+		}
+		name := benchName(fields[0])
+		byName[name] = append(byName[name], len(lines))
+		lines = append(lines, benchLine{
+			name:     name,
+			metrics:  customMetrics(fields),
+			metadata: metadata,
+		})
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	// every collected line is parsed in a single pass, after which
+	// the results of each benchmark are in the order of its lines
+	parsed, err := benchparse.ParseBenchmarks(strings.NewReader(buf.String()))
+	if err != nil {
+		return nil, err
+	}
+	results := make([]benchparse.BenchRes, len(lines))
+	for _, bench := range parsed {
+		indices := byName[bench.Name]
+		if len(indices) != len(bench.Results) {
+			return nil, fmt.Errorf("unexpected number of results for %s (expected=%d, actual=%d)", bench.Name, len(indices), len(bench.Results))
+		}
+		for i, res := range bench.Results {
+			results[indices[i]] = res
+		}
+	}
+
+	var (
+		benchmarks = []benchparse.Benchmark{}
+		positions  = map[string]int{}
+	)
+	for i, line := range lines {
+		res := results[i]
+		res.Outputs = Outputs{
+			BenchOutputs: res.Outputs,
+			Metrics:      line.metrics,
+			Metadata:     line.metadata,
+		}
+
+		pos, ok := positions[line.name]
+		if !ok {
+			pos = len(benchmarks)
+			positions[line.name] = pos
+			benchmarks = append(benchmarks, benchparse.Benchmark{Name: line.name, Results: []benchparse.BenchRes{}})
+		}
+		benchmarks[pos].Results = append(benchmarks[pos].Results, res)
+	}
+
+	return benchmarks, nil
+}
+
+// benchLine is what is known about a benchmark result line before
+// its outputs are parsed.
+type benchLine struct {
+	name     string
+	metrics  map[string]float64
+	metadata map[string]string
+}
+
+// isBenchLine reports whether the fields of a line are a benchmark
+// result, using the same rules as golang.org/x/tools/benchmark/parse
+// so that exactly these lines produce results.
+func isBenchLine(fields []string) bool {
+	if len(fields) < 2 || !strings.HasPrefix(fields[0], "Benchmark") {
+		return false
+	}
+	_, err := strconv.Atoi(fields[1])
+	return err == nil
+}
+
+// benchName returns the name of the top-level benchmark that
+// benchparse groups a result under, which is the name before any
+// sub-benchmarks or GOMAXPROCS suffix (e.g. 'BenchmarkFoo' for
+// 'BenchmarkFoo/n=2-8').
+func benchName(field string) string {
+	if i := strings.Index(field, "/"); i >= 0 {
+		return field[:i]
+	}
+	if m := procsSuffixExpr.FindStringSubmatch(field); m != nil {
+		return m[1]
+	}
+	return field
+}
+
+// matches the GOMAXPROCS suffix of a benchmark name without
+// sub-benchmarks, in the same way as benchparse
+var procsSuffixExpr = regexp.MustCompile(`^(Benchmark.+?)-[0-9]+$`)
+
+// metadataLine returns the key and value of a configuration line
+// (e.g. "goos: linux"), and whether the line is one.
+func metadataLine(line string) (string, string, bool) {
+	i := strings.Index(line, ":")
+	if i < 0 {
+		return "", "", false
+	}
+	key := line[:i]
+	for _, k := range metadataKeys {
+		if key == k {
+			return key, strings.TrimSpace(line[i+1:]), true
+		}
+	}
+	return "", "", false
+}
+
+// customMetrics returns the measurements among the fields of a
+// benchmark line which are not one of the standard outputs.
+func customMetrics(fields []string) map[string]float64 {
+	metrics := map[string]float64{}
+	// skip the name and number of iterations
+	for i := 2; i+1 < len(fields); i += 2 {
+		unit := fields[i+1]
+		if standardUnits[unit] {
+			continue
+		}
+		v, err := strconv.ParseFloat(fields[i], 64)
+		if err != nil {
+			continue
+		}
+		metrics[unit] = v
+	}
+	return metrics
+}
@@ -0,0 +1,170 @@
+package input
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ShawnROGrady/benchparse"
+)
+
+const sampleOutput = `goos: linux
+goarch: amd64
+pkg: github.com/example/cache
+BenchmarkGet/size=10-8         	 5000000	       250 ns/op	         0.95 hits/op	       310 p99-ns	      16 B/op	       1 allocs/op
+BenchmarkGet/size=100-8        	 3000000	       400 ns/op	         0.80 hits/op	       520 p99-ns	      16 B/op	       1 allocs/op
+BenchmarkPut/size=10-8         	 2000000	       600 ns/op	      64 B/op	       2 allocs/op
+BenchmarkGet/size=10-8         	 5000000	       260 ns/op	         0.96 hits/op	       300 p99-ns	      16 B/op	       1 allocs/op
+PASS
+ok  	github.com/example/cache	8.123s
+`
+
+type expectedResult struct {
+	inputs  string
+	nsPerOp float64
+	metrics map[string]float64
+}
+
+func TestParseBenchmarks(t *testing.T) {
+	benches, err := ParseBenchmarks(strings.NewReader(sampleOutput))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var (
+		expectedNames   = []string{"BenchmarkGet", "BenchmarkPut"}
+		expectedResults = map[string][]expectedResult{
+			"BenchmarkGet": {
+				{inputs: "/size=10-8", nsPerOp: 250, metrics: map[string]float64{"hits/op": 0.95, "p99-ns": 310}},
+				{inputs: "/size=100-8", nsPerOp: 400, metrics: map[string]float64{"hits/op": 0.80, "p99-ns": 520}},
+				{inputs: "/size=10-8", nsPerOp: 260, metrics: map[string]float64{"hits/op": 0.96, "p99-ns": 300}},
+			},
+			"BenchmarkPut": {
+				{inputs: "/size=10-8", nsPerOp: 600, metrics: map[string]float64{}},
+			},
+		}
+	)
+
+	names := make([]string, len(benches))
+	for i, bench := range benches {
+		names[i] = bench.Name
+	}
+	if !reflect.DeepEqual(names, expectedNames) {
+		t.Fatalf("unexpected benchmark names (expected=%v, actual=%v)", expectedNames, names)
+	}
+
+	for _, bench := range benches {
+		expected := expectedResults[bench.Name]
+		if len(bench.Results) != len(expected) {
+			t.Fatalf("unexpected number of results for %s (expected=%d, actual=%d)", bench.Name, len(expected), len(bench.Results))
+		}
+		for i, res := range bench.Results {
+			if s := res.Inputs.String(); s != expected[i].inputs {
+				t.Errorf("unexpected inputs for %s[%d] (expected=%s, actual=%s)", bench.Name, i, expected[i].inputs, s)
+			}
+
+			outputs, ok := res.Outputs.(Outputs)
+			if !ok {
+				t.Fatalf("unexpected outputs type: %T", res.Outputs)
+			}
+
+			nsPerOp, err := outputs.GetNsPerOp()
+			if err != nil {
+				t.Errorf("unexpected error getting ns/op: %s", err)
+			}
+			if nsPerOp != expected[i].nsPerOp {
+				t.Errorf("unexpected ns/op for %s[%d] (expected=%v, actual=%v)", bench.Name, i, expected[i].nsPerOp, nsPerOp)
+			}
+
+			if !reflect.DeepEqual(outputs.Metrics, expected[i].metrics) {
+				t.Errorf("unexpected metrics for %s[%d] (expected=%v, actual=%v)", bench.Name, i, expected[i].metrics, outputs.Metrics)
+			}
+		}
+	}
+}
+
+func TestParseBenchmarksMetadata(t *testing.T) {
+	const output = `goos: linux
+goarch: amd64
+pkg: github.com/example/cache
+cpu: Intel(R) Xeon(R) CPU @ 2.20GHz
+BenchmarkGet/size=10-8         	 5000000	       250 ns/op
+pkg: github.com/example/sort
+BenchmarkSort/n=10-8           	 1000000	      1000 ns/op
+`
+	benches, err := ParseBenchmarks(strings.NewReader(output))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := map[string]map[string]string{
+		"BenchmarkGet":  {"goos": "linux", "goarch": "amd64", "pkg": "github.com/example/cache", "cpu": "Intel(R) Xeon(R) CPU @ 2.20GHz"},
+		"BenchmarkSort": {"goos": "linux", "goarch": "amd64", "pkg": "github.com/example/sort", "cpu": "Intel(R) Xeon(R) CPU @ 2.20GHz"},
+	}
+	if len(benches) != len(expected) {
+		t.Fatalf("unexpected number of benchmarks (expected=%d, actual=%d)", len(expected), len(benches))
+	}
+	for _, bench := range benches {
+		outputs, ok := bench.Results[0].Outputs.(Outputs)
+		if !ok {
+			t.Fatalf("unexpected outputs type: %T", bench.Results[0].Outputs)
+		}
+		if !reflect.DeepEqual(outputs.Metadata, expected[bench.Name]) {
+			t.Errorf("unexpected metadata for %s (expected=%v, actual=%v)", bench.Name, expected[bench.Name], outputs.Metadata)
+		}
+		if keys := outputs.MetadataKeys(); !reflect.DeepEqual(keys, []string{"goos", "goarch", "pkg", "cpu"}) {
+			t.Errorf("unexpected metadata keys for %s: %v", bench.Name, keys)
+		}
+	}
+}
+
+func TestOutputsGetMetric(t *testing.T) {
+	o := Outputs{Metrics: map[string]float64{"hits/op": 0.5, "p99-ns": 300}}
+
+	v, err := o.GetMetric("hits/op")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if v != 0.5 {
+		t.Errorf("unexpected value (expected=%v, actual=%v)", 0.5, v)
+	}
+
+	if _, err := o.GetMetric("misses/op"); err != benchparse.ErrNotMeasured {
+		t.Errorf("unexpected error (expected=%s, actual=%v)", benchparse.ErrNotMeasured, err)
+	}
+
+	if units := o.MetricUnits(); !reflect.DeepEqual(units, []string{"hits/op", "p99-ns"}) {
+		t.Errorf("unexpected units: %v", units)
+	}
+}
+
+var benchNameTests = map[string]struct {
+	field        string
+	expectedName string
+}{
+	"no_procs":          {field: "BenchmarkGet", expectedName: "BenchmarkGet"},
+	"procs":             {field: "BenchmarkGet-8", expectedName: "BenchmarkGet"},
+	"sub":               {field: "BenchmarkGet/size=10", expectedName: "BenchmarkGet"},
+	"sub,procs":         {field: "BenchmarkGet/size=10-8", expectedName: "BenchmarkGet"},
+	"dash_in_name":      {field: "BenchmarkGet-Put-8", expectedName: "BenchmarkGet-Put"},
+	"only_procs_suffix": {field: "Benchmark-8", expectedName: "Benchmark-8"},
+}
+
+func TestBenchName(t *testing.T) {
+	for testName, testCase := range benchNameTests {
+		t.Run(testName, func(t *testing.T) {
+			if name := benchName(testCase.field); name != testCase.expectedName {
+				t.Errorf("unexpected name (expected=%s, actual=%s)", testCase.expectedName, name)
+			}
+
+			// the name must match how benchparse groups the result
+			parsed, err := benchparse.ParseBenchmarks(strings.NewReader(testCase.field + " 1 1 ns/op"))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(parsed) != 1 || parsed[0].Name != testCase.expectedName {
+				t.Errorf("unexpected benchparse benchmarks: %v", parsed)
+			}
+		})
+	}
+}
@@ -0,0 +1,364 @@
+package input
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ShawnROGrady/benchparse"
+)
+
+// The roles a column of tabular input may have. Any other role is the
+// unit of an output (e.g. 'ns/op' or a custom metric like 'hits/op'),
+// which must contain a '/'.
+const (
+	NameRole   = "name"   // the name of the benchmark
+	RunsRole   = "runs"   // the number of iterations
+	InputRole  = "input"  // an input variable named after the column
+	IgnoreRole = "ignore" // not used
+)
+
+// Columns maps the names of columns of tabular input to their roles.
+// Columns which are not mapped have the role of their name if it is
+// "name" or "runs", are outputs if their name is a unit (contains a
+// '/'), and are inputs otherwise.
+type Columns map[string]string
+
+// Role returns the role of the named column.
+func (c Columns) Role(column string) string {
+	if role, ok := c[column]; ok {
+		return role
+	}
+	switch {
+	case column == NameRole, column == RunsRole:
+		return column
+	case strings.Contains(column, "/"):
+		return column
+	default:
+		return InputRole
+	}
+}
+
+// Validate verifies that each role is one of the roles of a column or
+// the unit of an output.
+func (c Columns) Validate() error {
+	// use sorted keys for consistent iteration order
+	names := make([]string, len(c))
+	j := 0
+	for k := range c {
+		names[j] = k
+		j++
+	}
+	sort.Strings(names)
+
+	for _, column := range names {
+		switch role := c[column]; {
+		case role == NameRole, role == RunsRole, role == InputRole, role == IgnoreRole:
+		case strings.Contains(role, "/"):
+		default:
+			return fmt.Errorf("invalid role for column '%s': '%s' (options = %q, or a unit containing '/' such as 'ns/op')", column, role, []string{NameRole, RunsRole, InputRole, IgnoreRole})
+		}
+	}
+	return nil
+}
+
+var (
+	// ErrNoNameColumn indicates that a record of tabular input did not
+	// have a column with the name of the benchmark.
+	ErrNoNameColumn = errors.New("no column with role 'name'")
+
+	// errNotScalar indicates that a value of JSON input was an array
+	// or an object.
+	errNotScalar = errors.New("arrays and objects are not allowed")
+)
+
+// record is a row of tabular input.
+type record struct {
+	columns []string
+	values  map[string]string
+}
+
+// ParseRecords extracts a list of Benchmarks from tabular input in
+// the CSV or JSON format, where each record is the result of a single
+// benchmark run. CSV input must start with a header naming each column.
+// JSON input is either an array of objects or a sequence of objects,
+// whose values must not be arrays or objects.
+func ParseRecords(r io.Reader, format Format, columns Columns) ([]benchparse.Benchmark, error) {
+	if err := columns.Validate(); err != nil {
+		return nil, err
+	}
+
+	var (
+		records []record
+		err     error
+	)
+	switch format {
+	case CSVFormat:
+		records, err = readCSVRecords(r)
+	case JSONFormat:
+		records, err = readJSONRecords(r)
+	default:
+		return nil, fmt.Errorf("not a tabular input format: '%s' (options = %q)", format, []Format{CSVFormat, JSONFormat})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		benchmarks = []benchparse.Benchmark{}
+		positions  = map[string]int{}
+	)
+	for i, rec := range records {
+		name, res, err := recordResult(rec, columns)
+		if err != nil {
+			return nil, fmt.Errorf("record %d: %w", i+1, err)
+		}
+
+		pos, ok := positions[name]
+		if !ok {
+			pos = len(benchmarks)
+			positions[name] = pos
+			benchmarks = append(benchmarks, benchparse.Benchmark{Name: name, Results: []benchparse.BenchRes{}})
+		}
+		benchmarks[pos].Results = append(benchmarks[pos].Results, res)
+	}
+	return benchmarks, nil
+}
+
+// recordResult returns the name of the benchmark and the result
+// described by the record. Empty values are treated as missing.
+func recordResult(rec record, columns Columns) (string, benchparse.BenchRes, error) {
+	var (
+		name    string
+		inputs  = benchparse.BenchInputs{VarValues: []benchparse.BenchVarValue{}, Subs: []benchparse.BenchSub{}}
+		outputs = recordOutputs{measured: map[string]float64{}}
+		metrics = map[string]float64{}
+	)
+	for _, column := range rec.columns {
+		value := rec.values[column]
+		if value == "" {
+			continue
+		}
+
+		switch role := columns.Role(column); role {
+		case NameRole:
+			name = value
+		case RunsRole:
+			runs, err := strconv.Atoi(value)
+			if err != nil {
+				return "", benchparse.BenchRes{}, fmt.Errorf("invalid number of runs in column '%s': %w", column, err)
+			}
+			outputs.runs = runs
+		case InputRole:
+			inputs.VarValues = append(inputs.VarValues, benchparse.BenchVarValue{Name: column, Value: ParseValue(value)})
+		case IgnoreRole:
+		default:
+			v, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return "", benchparse.BenchRes{}, fmt.Errorf("invalid %s in column '%s': %w", role, column, err)
+			}
+			if standardUnits[role] {
+				outputs.measured[role] = v
+			} else {
+				metrics[role] = v
+			}
+		}
+	}
+	if name == "" {
+		return "", benchparse.BenchRes{}, ErrNoNameColumn
+	}
+
+	return name, benchparse.BenchRes{
+		Inputs:  inputs,
+		Outputs: Outputs{BenchOutputs: outputs, Metrics: metrics},
+	}, nil
+}
+
+// ParseValue converts the string to an int, float64, or bool if
+// possible, as is done by benchparse for var_name=var_value
+// components. It is used for every input not parsed by benchparse so
+// that all inputs of the same kind have the same type.
+func ParseValue(s string) interface{} {
+	if v, err := strconv.Atoi(s); err == nil {
+		return v
+	}
+	if v, err := strconv.ParseFloat(s, 64); err == nil {
+		return v
+	}
+	if v, err := strconv.ParseBool(s); err == nil {
+		return v
+	}
+	return s
+}
+
+// recordOutputs are the standard outputs of a result read from a
+// record, keyed by unit.
+type recordOutputs struct {
+	runs     int
+	measured map[string]float64
+}
+
+func (o recordOutputs) GetIterations() int {
+	return o.runs
+}
+
+// GetNsPerOp returns the nanoseconds per iteration.
+// If not measured benchparse.ErrNotMeasured is returned.
+func (o recordOutputs) GetNsPerOp() (float64, error) {
+	return o.get("ns/op")
+}
+
+// GetAllocedBytesPerOp returns the bytes allocated per iteration.
+// If not measured benchparse.ErrNotMeasured is returned.
+func (o recordOutputs) GetAllocedBytesPerOp() (uint64, error) {
+	v, err := o.get("B/op")
+	return uint64(v), err
+}
+
+// GetAllocsPerOp returns the allocs per iteration.
+// If not measured benchparse.ErrNotMeasured is returned.
+func (o recordOutputs) GetAllocsPerOp() (uint64, error) {
+	v, err := o.get("allocs/op")
+	return uint64(v), err
+}
+
+// GetMBPerS returns the MB processed per second.
+// If not measured benchparse.ErrNotMeasured is returned.
+func (o recordOutputs) GetMBPerS() (float64, error) {
+	return o.get("MB/s")
+}
+
+func (o recordOutputs) get(unit string) (float64, error) {
+	if v, ok := o.measured[unit]; ok {
+		return v, nil
+	}
+	return 0, benchparse.ErrNotMeasured
+}
+
+// readCSVRecords reads the records of CSV input with a header.
+func readCSVRecords(r io.Reader) ([]record, error) {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return []record{}, nil
+	}
+
+	var (
+		header  = make([]string, len(rows[0]))
+		records = make([]record, len(rows)-1)
+	)
+	for i, column := range rows[0] {
+		header[i] = strings.TrimSpace(column)
+	}
+	for i, row := range rows[1:] {
+		values := make(map[string]string, len(header))
+		for j, column := range header {
+			values[column] = strings.TrimSpace(row[j])
+		}
+		records[i] = record{columns: header, values: values}
+	}
+	return records, nil
+}
+
+// readJSONRecords reads the records of JSON input, preserving the
+// order of the keys of each object.
+func readJSONRecords(r io.Reader) ([]record, error) {
+	var (
+		dec     = json.NewDecoder(r)
+		records = []record{}
+	)
+	dec.UseNumber()
+
+	t, err := dec.Token()
+	if err == io.EOF {
+		return records, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if t == json.Delim('[') {
+		for dec.More() {
+			t, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			rec, err := readJSONObject(dec, t)
+			if err != nil {
+				return nil, err
+			}
+			records = append(records, rec)
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		return records, nil
+	}
+
+	for {
+		rec, err := readJSONObject(dec, t)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, rec)
+
+		t, err = dec.Token()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// readJSONObject reads the object starting with the token t.
+func readJSONObject(dec *json.Decoder, t json.Token) (record, error) {
+	if t != json.Delim('{') {
+		return record{}, fmt.Errorf("expected an object, found '%v'", t)
+	}
+
+	rec := record{columns: []string{}, values: map[string]string{}}
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return record{}, err
+		}
+		column, ok := t.(string)
+		if !ok {
+			return record{}, fmt.Errorf("unexpected key: '%v'", t)
+		}
+
+		t, err = dec.Token()
+		if err != nil {
+			return record{}, err
+		}
+		var value string
+		switch v := t.(type) {
+		case json.Number:
+			value = v.String()
+		case string:
+			value = v
+		case bool:
+			value = strconv.FormatBool(v)
+		case nil:
+		default:
+			return record{}, fmt.Errorf("unsupported value of '%s': %w", column, errNotScalar)
+		}
+		if _, ok := rec.values[column]; !ok {
+			rec.columns = append(rec.columns, column)
+		}
+		rec.values[column] = value
+	}
+	if _, err := dec.Token(); err != nil {
+		return record{}, err
+	}
+	return rec, nil
+}
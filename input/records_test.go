@@ -0,0 +1,187 @@
+package input
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ShawnROGrady/benchparse"
+)
+
+type expectedRecordResult struct {
+	varValues []benchparse.BenchVarValue
+	runs      int
+	nsPerOp   float64
+	bytes     uint64
+	metrics   map[string]float64
+}
+
+var parseRecordsTests = map[string]struct {
+	input           string
+	format          Format
+	columns         Columns
+	expectedNames   []string
+	expectedResults map[string][]expectedRecordResult
+	expectErr       bool
+}{
+	"csv": {
+		input: "name, n, impl, runs, ns/op, B/op, hits/op\n" +
+			"BenchmarkGet,10,lru,1000,250,16,0.95\n" +
+			"BenchmarkGet,100,lru,1000,400,16,\n" +
+			"BenchmarkPut,10,lru,500,600,64,\n",
+		format:        CSVFormat,
+		expectedNames: []string{"BenchmarkGet", "BenchmarkPut"},
+		expectedResults: map[string][]expectedRecordResult{
+			"BenchmarkGet": {
+				{varValues: []benchparse.BenchVarValue{{Name: "n", Value: 10}, {Name: "impl", Value: "lru"}}, runs: 1000, nsPerOp: 250, bytes: 16, metrics: map[string]float64{"hits/op": 0.95}},
+				{varValues: []benchparse.BenchVarValue{{Name: "n", Value: 100}, {Name: "impl", Value: "lru"}}, runs: 1000, nsPerOp: 400, bytes: 16, metrics: map[string]float64{}},
+			},
+			"BenchmarkPut": {
+				{varValues: []benchparse.BenchVarValue{{Name: "n", Value: 10}, {Name: "impl", Value: "lru"}}, runs: 500, nsPerOp: 600, bytes: 64, metrics: map[string]float64{}},
+			},
+		},
+	},
+	"csv,columns": {
+		input: "bench,size,time_ns,host\n" +
+			"get,1.5,250,a\n",
+		format:        CSVFormat,
+		columns:       Columns{"bench": NameRole, "time_ns": "ns/op", "host": IgnoreRole},
+		expectedNames: []string{"get"},
+		expectedResults: map[string][]expectedRecordResult{
+			"get": {
+				{varValues: []benchparse.BenchVarValue{{Name: "size", Value: 1.5}}, nsPerOp: 250, metrics: map[string]float64{}},
+			},
+		},
+	},
+	"json,array": {
+		input: `[
+			{"name": "BenchmarkGet", "n": 10, "parallel": true, "ns/op": 250, "p99-ns": 310},
+			{"name": "BenchmarkGet", "n": 100, "parallel": false, "ns/op": 400, "p99-ns": null}
+		]`,
+		format:        JSONFormat,
+		columns:       Columns{"p99-ns": "p99-ns/op"},
+		expectedNames: []string{"BenchmarkGet"},
+		expectedResults: map[string][]expectedRecordResult{
+			"BenchmarkGet": {
+				{varValues: []benchparse.BenchVarValue{{Name: "n", Value: 10}, {Name: "parallel", Value: true}}, nsPerOp: 250, metrics: map[string]float64{"p99-ns/op": 310}},
+				{varValues: []benchparse.BenchVarValue{{Name: "n", Value: 100}, {Name: "parallel", Value: false}}, nsPerOp: 400, metrics: map[string]float64{}},
+			},
+		},
+	},
+	"json,objects": {
+		input: `{"name": "BenchmarkGet", "impl": "lru", "ns/op": "250"}
+{"name": "BenchmarkGet", "impl": "arc", "ns/op": 260}`,
+		format:        JSONFormat,
+		expectedNames: []string{"BenchmarkGet"},
+		expectedResults: map[string][]expectedRecordResult{
+			"BenchmarkGet": {
+				{varValues: []benchparse.BenchVarValue{{Name: "impl", Value: "lru"}}, nsPerOp: 250, metrics: map[string]float64{}},
+				{varValues: []benchparse.BenchVarValue{{Name: "impl", Value: "arc"}}, nsPerOp: 260, metrics: map[string]float64{}},
+			},
+		},
+	},
+	"json,nested_value": {
+		input:     `[{"name": "BenchmarkGet", "n": [10]}]`,
+		format:    JSONFormat,
+		expectErr: true,
+	},
+	"csv,no_name": {
+		input:     "bench,ns/op\nget,250\n",
+		format:    CSVFormat,
+		expectErr: true,
+	},
+	"csv,invalid_role": {
+		input:     "bench,ns/op\nget,250\n",
+		format:    CSVFormat,
+		columns:   Columns{"bench": "title"},
+		expectErr: true,
+	},
+	"csv,invalid_output": {
+		input:     "name,ns/op\nget,fast\n",
+		format:    CSVFormat,
+		expectErr: true,
+	},
+	"text": {
+		input:     sampleOutput,
+		format:    TextFormat,
+		expectErr: true,
+	},
+}
+
+func TestParseRecords(t *testing.T) {
+	for testName, testCase := range parseRecordsTests {
+		t.Run(testName, func(t *testing.T) {
+			benches, err := ParseRecords(strings.NewReader(testCase.input), testCase.format, testCase.columns)
+			if err != nil {
+				if !testCase.expectErr {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			if testCase.expectErr {
+				t.Fatalf("unexpectedly no error")
+			}
+
+			names := make([]string, len(benches))
+			for i, bench := range benches {
+				names[i] = bench.Name
+			}
+			if !reflect.DeepEqual(names, testCase.expectedNames) {
+				t.Fatalf("unexpected benchmark names (expected=%v, actual=%v)", testCase.expectedNames, names)
+			}
+
+			for _, bench := range benches {
+				expected := testCase.expectedResults[bench.Name]
+				if len(bench.Results) != len(expected) {
+					t.Fatalf("unexpected number of results for %s (expected=%d, actual=%d)", bench.Name, len(expected), len(bench.Results))
+				}
+				for i, res := range bench.Results {
+					if !reflect.DeepEqual(res.Inputs.VarValues, expected[i].varValues) {
+						t.Errorf("unexpected inputs for %s[%d] (expected=%v, actual=%v)", bench.Name, i, expected[i].varValues, res.Inputs.VarValues)
+					}
+
+					outputs, ok := res.Outputs.(Outputs)
+					if !ok {
+						t.Fatalf("unexpected outputs type: %T", res.Outputs)
+					}
+					if runs := outputs.GetIterations(); runs != expected[i].runs {
+						t.Errorf("unexpected runs for %s[%d] (expected=%d, actual=%d)", bench.Name, i, expected[i].runs, runs)
+					}
+					if nsPerOp, _ := outputs.GetNsPerOp(); nsPerOp != expected[i].nsPerOp {
+						t.Errorf("unexpected ns/op for %s[%d] (expected=%v, actual=%v)", bench.Name, i, expected[i].nsPerOp, nsPerOp)
+					}
+					bytes, err := outputs.GetAllocedBytesPerOp()
+					if expected[i].bytes == 0 && err != benchparse.ErrNotMeasured {
+						t.Errorf("unexpected error getting B/op for %s[%d] (expected=%s, actual=%v)", bench.Name, i, benchparse.ErrNotMeasured, err)
+					}
+					if bytes != expected[i].bytes {
+						t.Errorf("unexpected B/op for %s[%d] (expected=%v, actual=%v)", bench.Name, i, expected[i].bytes, bytes)
+					}
+					if !reflect.DeepEqual(outputs.Metrics, expected[i].metrics) {
+						t.Errorf("unexpected metrics for %s[%d] (expected=%v, actual=%v)", bench.Name, i, expected[i].metrics, outputs.Metrics)
+					}
+				}
+			}
+		})
+	}
+}
+
+var parseValueTests = map[string]struct {
+	s             string
+	expectedValue interface{}
+}{
+	"int":    {s: "1024", expectedValue: 1024},
+	"float":  {s: "0.5", expectedValue: 0.5},
+	"bool":   {s: "true", expectedValue: true},
+	"string": {s: "quick", expectedValue: "quick"},
+}
+
+func TestParseValue(t *testing.T) {
+	for testName, testCase := range parseValueTests {
+		t.Run(testName, func(t *testing.T) {
+			if v := ParseValue(testCase.s); !reflect.DeepEqual(v, testCase.expectedValue) {
+				t.Errorf("unexpected value (expected=%#v, actual=%#v)", testCase.expectedValue, v)
+			}
+		})
+	}
+}
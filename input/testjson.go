@@ -0,0 +1,72 @@
+package input
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+// maxEventSize is the maximum size of a single test2json event.
+const maxEventSize = 1024 * 1024
+
+// testEvent is an event emitted by test2json (e.g. by 'go test -json').
+// Only the fields needed to recover the output are decoded.
+type testEvent struct {
+	Action  string
+	Package string
+	Output  string
+}
+
+// decodeTestJSON returns the output recorded by a stream of test2json
+// events. The output of each package is kept together, in the order
+// each package is first seen, since events from packages tested in
+// parallel may be interleaved. This rejoins lines split across multiple
+// events (as benchmark result lines are) and keeps the configuration
+// lines of each package (e.g. "pkg: ...") with its results. Lines which
+// are not events, such as build errors, are kept after the output of
+// the packages.
+func decodeTestJSON(r io.Reader) (io.Reader, error) {
+	var (
+		scanner  = bufio.NewScanner(r)
+		outputs  = map[string]*strings.Builder{}
+		packages []string
+		other    strings.Builder
+	)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxEventSize)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		var event testEvent
+		if err := json.Unmarshal(line, &event); err != nil || event.Action == "" {
+			other.Write(line)
+			other.WriteByte('\n')
+			continue
+		}
+		if event.Action != "output" {
+			continue
+		}
+
+		output, ok := outputs[event.Package]
+		if !ok {
+			output = &strings.Builder{}
+			outputs[event.Package] = output
+			packages = append(packages, event.Package)
+		}
+		output.WriteString(event.Output)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	var decoded bytes.Buffer
+	for _, pkg := range packages {
+		s := outputs[pkg].String()
+		decoded.WriteString(s)
+		if s != "" && !strings.HasSuffix(s, "\n") {
+			decoded.WriteByte('\n')
+		}
+	}
+	decoded.WriteString(other.String())
+	return &decoded, nil
+}
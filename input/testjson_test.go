@@ -0,0 +1,168 @@
+package input
+
+import (
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// sampleTestJSON is the output of 'go test -json -bench' for two
+// packages tested in parallel, with result lines split across events.
+const sampleTestJSON = `{"Time":"2026-10-18T10:00:00Z","Action":"start","Package":"example/cache"}
+{"Time":"2026-10-18T10:00:00Z","Action":"output","Package":"example/cache","Output":"goos: linux\n"}
+{"Time":"2026-10-18T10:00:00Z","Action":"output","Package":"example/cache","Output":"goarch: amd64\n"}
+{"Time":"2026-10-18T10:00:00Z","Action":"output","Package":"example/cache","Output":"pkg: example/cache\n"}
+{"Time":"2026-10-18T10:00:01Z","Action":"run","Package":"example/cache","Test":"BenchmarkGet"}
+{"Time":"2026-10-18T10:00:01Z","Action":"output","Package":"example/cache","Test":"BenchmarkGet","Output":"=== RUN   BenchmarkGet\n"}
+{"Time":"2026-10-18T10:00:01Z","Action":"output","Package":"example/cache","Test":"BenchmarkGet/size=10","Output":"BenchmarkGet/size=10-8         \t"}
+{"Time":"2026-10-18T10:00:01Z","Action":"output","Package":"example/sort","Output":"goos: linux\n"}
+{"Time":"2026-10-18T10:00:01Z","Action":"output","Package":"example/sort","Test":"BenchmarkSort/n=10","Output":"BenchmarkSort/n=10-8   \t"}
+{"Time":"2026-10-18T10:00:02Z","Action":"output","Package":"example/cache","Test":"BenchmarkGet/size=10","Output":" 5000000\t       250 ns/op\t         0.95 hits/op\n"}
+{"Time":"2026-10-18T10:00:02Z","Action":"output","Package":"example/sort","Test":"BenchmarkSort/n=10","Output":" 1000000\t      1000 ns/op\n"}
+{"Time":"2026-10-18T10:00:02Z","Action":"output","Package":"example/cache","Test":"BenchmarkGet/size=100","Output":"BenchmarkGet/size=100-8        \t 3000000\t       400 ns/op\t         0.80 hits/op\n"}
+{"Time":"2026-10-18T10:00:03Z","Action":"pass","Package":"example/cache","Test":"BenchmarkGet","Elapsed":2}
+{"Time":"2026-10-18T10:00:03Z","Action":"output","Package":"example/cache","Output":"PASS\n"}
+{"Time":"2026-10-18T10:00:03Z","Action":"pass","Package":"example/cache","Elapsed":3}
+`
+
+var decodeTestJSONTests = map[string]struct {
+	input          string
+	expectedOutput string
+}{
+	"interleaved_packages": {
+		input: sampleTestJSON + "# example/broken\nbroken.go:3:1: syntax error\n",
+		expectedOutput: "goos: linux\n" +
+			"goarch: amd64\n" +
+			"pkg: example/cache\n" +
+			"=== RUN   BenchmarkGet\n" +
+			"BenchmarkGet/size=10-8         \t 5000000\t       250 ns/op\t         0.95 hits/op\n" +
+			"BenchmarkGet/size=100-8        \t 3000000\t       400 ns/op\t         0.80 hits/op\n" +
+			"PASS\n" +
+			"goos: linux\n" +
+			"BenchmarkSort/n=10-8   \t 1000000\t      1000 ns/op\n" +
+			"# example/broken\n" +
+			"broken.go:3:1: syntax error\n",
+	},
+	"split_line": {
+		input: `{"Action":"output","Package":"a","Output":"BenchmarkA-8 \t"}
+{"Action":"output","Package":"a","Output":" 100\t 10 ns/op\n"}
+`,
+		expectedOutput: "BenchmarkA-8 \t 100\t 10 ns/op\n",
+	},
+	"package_config_kept_with_results": {
+		input: `{"Action":"output","Package":"a","Output":"pkg: a\n"}
+{"Action":"output","Package":"b","Output":"pkg: b\n"}
+{"Action":"output","Package":"a","Output":"BenchmarkA-8 \t 100\t 10 ns/op\n"}
+{"Action":"output","Package":"b","Output":"BenchmarkB-8 \t 100\t 20 ns/op\n"}
+`,
+		expectedOutput: "pkg: a\n" +
+			"BenchmarkA-8 \t 100\t 10 ns/op\n" +
+			"pkg: b\n" +
+			"BenchmarkB-8 \t 100\t 20 ns/op\n",
+	},
+	"no_trailing_newline": {
+		input: `{"Action":"output","Package":"a","Output":"BenchmarkA-8 \t 100\t 10 ns/op"}
+{"Action":"output","Package":"b","Output":"BenchmarkB-8 \t 100\t 20 ns/op\n"}
+`,
+		expectedOutput: "BenchmarkA-8 \t 100\t 10 ns/op\n" +
+			"BenchmarkB-8 \t 100\t 20 ns/op\n",
+	},
+}
+
+func TestDecodeTestJSON(t *testing.T) {
+	for testName, testCase := range decodeTestJSONTests {
+		t.Run(testName, func(t *testing.T) {
+			r, err := decodeTestJSON(strings.NewReader(testCase.input))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			output, err := ioutil.ReadAll(r)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if string(output) != testCase.expectedOutput {
+				t.Errorf("unexpected output\nexpected:\n%q\nactual:\n%q", testCase.expectedOutput, output)
+			}
+		})
+	}
+}
+
+var parseFormatTests = map[string]struct {
+	input         string
+	format        Format
+	expectedNames []string
+	expectErr     bool
+}{
+	"auto,text": {
+		input:         sampleOutput,
+		format:        AutoFormat,
+		expectedNames: []string{"BenchmarkGet", "BenchmarkPut"},
+	},
+	"auto,test2json": {
+		input:         "\n" + sampleTestJSON,
+		format:        AutoFormat,
+		expectedNames: []string{"BenchmarkGet", "BenchmarkSort"},
+	},
+	"test2json": {
+		input:         sampleTestJSON,
+		format:        TestJSONFormat,
+		expectedNames: []string{"BenchmarkGet", "BenchmarkSort"},
+	},
+	"auto,json_array": {
+		input:         ` [{"name": "BenchmarkGet", "ns/op": 250}]`,
+		format:        AutoFormat,
+		expectedNames: []string{"BenchmarkGet"},
+	},
+	"auto,json_objects": {
+		input:         `{"name": "BenchmarkGet", "ns/op": 250}` + "\n" + `{"name": "BenchmarkPut", "ns/op": 600}`,
+		format:        AutoFormat,
+		expectedNames: []string{"BenchmarkGet", "BenchmarkPut"},
+	},
+	"auto,csv": {
+		input:         "\nname,n,ns/op\nBenchmarkGet,10,250\nBenchmarkPut,10,600\n",
+		format:        AutoFormat,
+		expectedNames: []string{"BenchmarkGet", "BenchmarkPut"},
+	},
+	"auto,csv,no_name_column": {
+		input:         "bench,n,ns/op\nBenchmarkGet,10,250\n",
+		format:        AutoFormat,
+		expectedNames: []string{},
+	},
+	"text,test2json_input": {
+		input:         sampleTestJSON,
+		format:        TextFormat,
+		expectedNames: []string{},
+	},
+	"unknown_format": {
+		input:     sampleOutput,
+		format:    "xml",
+		expectErr: true,
+	},
+}
+
+func TestParseFormat(t *testing.T) {
+	for testName, testCase := range parseFormatTests {
+		t.Run(testName, func(t *testing.T) {
+			benches, err := ParseFormat(strings.NewReader(testCase.input), testCase.format, nil)
+			if err != nil {
+				if !testCase.expectErr {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			if testCase.expectErr {
+				t.Fatalf("unexpectedly no error")
+			}
+
+			names := make([]string, len(benches))
+			for i, bench := range benches {
+				names[i] = bench.Name
+			}
+			if !reflect.DeepEqual(names, testCase.expectedNames) {
+				t.Errorf("unexpected benchmark names (expected=%v, actual=%v)", testCase.expectedNames, names)
+			}
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package plot
 
-import "github.com/ShawnROGrady/benchparse"
+import (
+	"sort"
+
+	"github.com/ShawnROGrady/benchparse"
+)
 
 // benchOutputs is a mock implementation of benchparse.BenchOutputs
 type benchOutputs struct {
@@ -85,3 +89,34 @@ func newTestOutputs(n int, opts ...benchOutOption) benchparse.BenchOutputs {
 	}
 	return b
 }
+
+// metricBenchOutputs is a mock implementation of metricOutputs
+type metricBenchOutputs struct {
+	benchparse.BenchOutputs
+	metrics map[string]float64
+}
+
+// GetMetric returns the metric with the specified unit
+func (_m *metricBenchOutputs) GetMetric(unit string) (float64, error) {
+	if v, ok := _m.metrics[unit]; ok {
+		return v, nil
+	}
+	return 0, benchparse.ErrNotMeasured
+}
+
+// MetricUnits returns the units of the metrics in sorted order
+func (_m *metricBenchOutputs) MetricUnits() []string {
+	units := make([]string, 0, len(_m.metrics))
+	for unit := range _m.metrics {
+		units = append(units, unit)
+	}
+	sort.Strings(units)
+	return units
+}
+
+func newTestMetricOutputs(n int, metrics map[string]float64, opts ...benchOutOption) benchparse.BenchOutputs {
+	return &metricBenchOutputs{
+		BenchOutputs: newTestOutputs(n, opts...),
+		metrics:      metrics,
+	}
+}
@@ -3,8 +3,11 @@ package plot
 import (
 	"errors"
 	"fmt"
+	"io"
 	"reflect"
+	"regexp"
 	"sort"
+	"strings"
 
 	"github.com/ShawnROGrady/benchparse"
 	"github.com/ShawnROGrady/benchplot/plot/plotter"
@@ -12,45 +15,184 @@ import (
 
 // The available plot types.
 const (
-	ScatterType = "scatter"
-	AvgLineType = "avg_line"
+	ScatterType   = "scatter"
+	AvgLineType   = "avg_line"
+	AggLineType   = "agg_line"
+	BarType       = "bar"
+	AvgErrBarType = "avg_errbar"
+	BoxType       = "box"
+	FitType       = "fit"
+	ScalingType   = "scaling"
+)
+
+// The available kinds of error bars.
+const (
+	StdDevErr = "stddev"
+	StdErrErr = "stderr"
+	MinMaxErr = "minmax"
 )
 
 type plotOptions struct {
-	groupBy     []string
-	plotTypes   []string
-	filterExprs []string
+	groupBy      []string
+	plotTypes    []string
+	filterExprs  []string
+	namePattern  string
+	errKind      string
+	normalizeTo  string
+	yUnit        string
+	xScale       string
+	yScale       string
+	aggregation  string
+	alpha        float64
+	compareTo    string
+	summary      io.Writer
+	outliers     string
+	showOutliers bool
+}
+
+// LabeledBenchmark is a benchmark along with a label used to
+// distinguish its results from those of other benchmarks.
+type LabeledBenchmark struct {
+	Label     string
+	Benchmark benchparse.Benchmark
 }
 
 // Benchmark plots the benchmark.
 func Benchmark(b benchparse.Benchmark, p plotter.Plotter, xName, yName string, options ...plotOption) error {
+	return Compare([]LabeledBenchmark{{Benchmark: b}}, p, xName, yName, options...)
+}
+
+// Benchmarks plots multiple benchmarks on the same figure, with the
+// results of each benchmark labeled by the benchmark's name.
+func Benchmarks(benches []benchparse.Benchmark, p plotter.Plotter, xName, yName string, options ...plotOption) error {
+	labeledBenches := make([]LabeledBenchmark, len(benches))
+	for i, b := range benches {
+		labeledBenches[i] = LabeledBenchmark{Label: b.Name, Benchmark: b}
+	}
+	return Compare(labeledBenches, p, xName, yName, options...)
+}
+
+// Compare plots multiple benchmarks on the same figure. The results
+// of each benchmark are plotted as separate series, identified by
+// the benchmark's label along with any groups.
+func Compare(benches []LabeledBenchmark, p plotter.Plotter, xName, yName string, options ...plotOption) error {
 	pltOptions := &plotOptions{
 		groupBy:     []string{},
 		plotTypes:   []string{},
 		filterExprs: []string{},
+		errKind:     StdDevErr,
 	}
 	for _, opt := range options {
 		opt.apply(pltOptions)
 	}
+	if pltOptions.normalizeTo != "" && pltOptions.yUnit != "" {
+		return errUnitNormalized
+	}
+	if pltOptions.aggregation != "" && containsString(pltOptions.plotTypes, AvgLineType) {
+		return errAggAvgLine
+	}
+
+	var namePattern *regexp.Regexp
+	if pltOptions.namePattern != "" {
+		var err error
+		namePattern, err = regexp.Compile(pltOptions.namePattern)
+		if err != nil {
+			return fmt.Errorf("invalid name pattern: %w", err)
+		}
+	}
 
 	var (
-		res = b.Results
-		err error
+		grouped  = benchparse.GroupedResults{}
+		names    = []string{}
+		labels   = []string{}
+		filtered = make([]benchparse.BenchResults, 0, len(benches))
 	)
+	for _, bench := range benches {
+		var (
+			res = deriveInputs(bench.Benchmark.Results, namePattern)
+			err error
+		)
 
-	for _, expr := range pltOptions.filterExprs {
-		res, err = res.Filter(expr)
-		if err != nil {
-			return err
+		for _, expr := range pltOptions.filterExprs {
+			res, err = res.Filter(expr)
+			if err != nil {
+				return err
+			}
+		}
+
+		filtered = append(filtered, res)
+
+		for groupName, groupRes := range res.Group(pltOptions.groupBy) {
+			k := labeledGroupName(bench.Label, groupName)
+			grouped[k] = append(grouped[k], groupRes...)
+		}
+
+		if !containsString(names, bench.Benchmark.Name) {
+			names = append(names, bench.Benchmark.Name)
+		}
+		if bench.Label != "" && !containsString(labels, bench.Label) {
+			labels = append(labels, bench.Label)
+		}
+	}
+	title := strings.Join(names, ", ")
+	if subtitle := metadataSubtitle(filtered); subtitle != "" {
+		if err := p.SetSubtitle(subtitle); err != nil {
+			return fmt.Errorf("error setting subtitle: %w", err)
 		}
 	}
 
-	grouped := res.Group(pltOptions.groupBy)
 	splitGrouped, err := splitGroupedResult(grouped, xName, yName)
 	if err != nil {
 		return fmt.Errorf("err splitting grouped results: %w", err)
 	}
 
+	outliers := map[string][]splitRes{}
+	if pltOptions.outliers != "" {
+		splitGrouped, outliers, err = removeOutliers(splitGrouped, pltOptions.outliers)
+		if err != nil {
+			return fmt.Errorf("error removing outliers: %w", err)
+		}
+		if pltOptions.summary != nil {
+			if err := writeOutlierSummary(pltOptions.summary, title, pltOptions.outliers, splitGrouped, outliers); err != nil {
+				return fmt.Errorf("error writing summary: %w", err)
+			}
+		}
+	}
+
+	yLabel := yName
+	if pltOptions.normalizeTo != "" {
+		// outliers are normalized to the baseline without its outliers
+		outliers, err = normalizeSplitGroupedTo(outliers, splitGrouped, xName, pltOptions.normalizeTo)
+		if err != nil {
+			return fmt.Errorf("error normalizing outliers: %w", err)
+		}
+		splitGrouped, err = normalizeSplitGrouped(splitGrouped, xName, pltOptions.normalizeTo)
+		if err != nil {
+			return fmt.Errorf("error normalizing results: %w", err)
+		}
+		yLabel = fmt.Sprintf("%s (relative to %s)", yName, pltOptions.normalizeTo)
+	} else if units := outputUnits(yName); units != nil {
+		u, err := selectUnit(splitGrouped, units, pltOptions.yUnit)
+		if err != nil {
+			return fmt.Errorf("error selecting unit for %s: %w", yName, err)
+		}
+		splitGrouped, err = scaleSplitGrouped(splitGrouped, u)
+		if err != nil {
+			return err
+		}
+		outliers, err = scaleSplitGrouped(outliers, u)
+		if err != nil {
+			return err
+		}
+		yLabel = fmt.Sprintf("%s (%s)", yName, u.name)
+	}
+
+	if pltOptions.xScale != "" || pltOptions.yScale != "" {
+		if err := setScales(p, pltOptions.xScale, pltOptions.yScale); err != nil {
+			return err
+		}
+	}
+
 	if len(pltOptions.plotTypes) == 0 {
 		plotTypes, err := defaultPlotTypes(splitGrouped)
 		if err != nil {
@@ -59,24 +201,152 @@ func Benchmark(b benchparse.Benchmark, p plotter.Plotter, xName, yName string, o
 		pltOptions.plotTypes = plotTypes
 	}
 
+	for _, plotType := range pltOptions.plotTypes {
+		if plotType == ScalingType && (len(pltOptions.plotTypes) > 1 || pltOptions.alpha > 0 || pltOptions.normalizeTo != "") {
+			// the y values are speedups rather than those of the results
+			return errScalingCombined
+		}
+	}
+
 	for i, plotType := range pltOptions.plotTypes {
 		includeLegend := i == 0
 		switch plotType {
 		case ScatterType:
-			if err := plotScatter(p, b.Name, xName, yName, splitGrouped, includeLegend); err != nil {
+			if err := plotScatter(p, title, xName, yLabel, splitGrouped, includeLegend); err != nil {
 				return fmt.Errorf("error creating scatter plot: %w", err)
 			}
+			if pltOptions.showOutliers && len(outliers) != 0 {
+				if err := plotOutliers(p, title, xName, yLabel, outliers); err != nil {
+					return fmt.Errorf("error plotting outliers: %w", err)
+				}
+			}
 		case AvgLineType:
-			if err := plotAvgLine(p, b.Name, xName, yName, splitGrouped, includeLegend); err != nil {
+			if err := plotAvgLine(p, title, xName, yLabel, splitGrouped, includeLegend); err != nil {
 				return fmt.Errorf("error creating average line plot: %w", err)
 			}
+		case AggLineType:
+			agg := pltOptions.aggregation
+			if agg == "" {
+				agg = MeanAgg
+			}
+			if err := plotAggLine(p, title, xName, yLabel, splitGrouped, agg, includeLegend); err != nil {
+				return fmt.Errorf("error creating %s line plot: %w", agg, err)
+			}
+		case BarType:
+			if err := plotBar(p, title, xName, yLabel, splitGrouped, includeLegend); err != nil {
+				return fmt.Errorf("error creating bar plot: %w", err)
+			}
+		case AvgErrBarType:
+			if err := plotAvgErrBar(p, title, xName, yLabel, splitGrouped, pltOptions.errKind, includeLegend); err != nil {
+				return fmt.Errorf("error creating average error bar plot: %w", err)
+			}
+		case BoxType:
+			if err := plotBox(p, title, xName, yLabel, splitGrouped, includeLegend); err != nil {
+				return fmt.Errorf("error creating box plot: %w", err)
+			}
+		case FitType:
+			if err := plotFit(p, title, xName, yLabel, splitGrouped, pltOptions.xScale, pltOptions.summary); err != nil {
+				return fmt.Errorf("error creating fit plot: %w", err)
+			}
+		case ScalingType:
+			if err := plotScaling(p, title, xName, yName, splitGrouped, pltOptions.summary); err != nil {
+				return fmt.Errorf("error creating scaling plot: %w", err)
+			}
 		default:
 			return fmt.Errorf("unknown plot type: %s", plotType)
 		}
 	}
+
+	if pltOptions.alpha > 0 {
+		baseline := pltOptions.compareTo
+		if baseline == "" {
+			baseline = pltOptions.normalizeTo
+		}
+		if baseline == "" && len(labels) > 1 {
+			// compare each labeled input to the first
+			baseline = labels[0]
+		}
+		if err := plotSignificance(p, title, xName, yLabel, splitGrouped, baseline, pltOptions); err != nil {
+			return fmt.Errorf("error testing significance: %w", err)
+		}
+	}
 	return nil
 }
 
+// plotSignificance marks whether each group differs significantly
+// from its baseline at each x, and writes a summary of the
+// differences if requested. Points are only marked for numeric x
+// values.
+func plotSignificance(p plotter.Plotter, title, xName, yLabel string, splitGrouped map[string][]splitRes, baseline string, pltOptions *plotOptions) error {
+	results, err := compareSplitGrouped(splitGrouped, baseline)
+	if err != nil {
+		return err
+	}
+	if pltOptions.summary != nil {
+		if err := writeSignificanceSummary(pltOptions.summary, title, xName, pltOptions.alpha, results); err != nil {
+			return fmt.Errorf("error writing summary: %w", err)
+		}
+	}
+
+	xs := make([]interface{}, len(results))
+	for i, res := range results {
+		xs[i] = res.x
+	}
+	if !allNumeric(xs) {
+		return nil
+	}
+
+	data, err := significanceData(splitGrouped, results)
+	if err != nil {
+		return err
+	}
+	return p.PlotSignificance(data, pltOptions.alpha, title, xName, yLabel)
+}
+
+// setScales sets the scales of the plot's axes, with unspecified
+// scales defaulting to linear.
+func setScales(p plotter.Plotter, xScale, yScale string) error {
+	scales := make([]plotter.Scale, 2)
+	for i, scale := range []string{xScale, yScale} {
+		switch s := plotter.Scale(scale); s {
+		case "":
+			scales[i] = plotter.LinearScale
+		case plotter.LinearScale, plotter.Log2Scale, plotter.Log10Scale:
+			scales[i] = s
+		default:
+			return fmt.Errorf("unknown scale: %s", scale)
+		}
+	}
+	if err := p.SetScales(scales[0], scales[1]); err != nil {
+		return fmt.Errorf("error setting scales: %w", err)
+	}
+	return nil
+}
+
+// labeledGroupName returns the name of a group of results from
+// a labeled benchmark.
+func labeledGroupName(label, groupName string) string {
+	switch {
+	case label == "":
+		return groupName
+	case groupName == "":
+		return label
+	default:
+		return label + "," + groupName
+	}
+}
+
+func containsString(vals []string, s string) bool {
+	for _, val := range vals {
+		if val == s {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultPlotTypes returns the plots to create when none are specified,
+// based on the kind of the x values.
 func defaultPlotTypes(splitGrouped map[string][]splitRes) ([]string, error) {
 	// just use the first x value
 	for _, res := range splitGrouped {
@@ -86,7 +356,9 @@ func defaultPlotTypes(splitGrouped map[string][]splitRes) ([]string, error) {
 		xKind := reflect.TypeOf(res[0].x).Kind()
 		switch xKind {
 		case reflect.Int, reflect.Float64, reflect.Uint64:
-			return []string{ScatterType, AvgLineType}, nil
+			return []string{ScatterType, AggLineType}, nil
+		case reflect.String, reflect.Bool:
+			return []string{BarType}, nil
 		}
 	}
 	return []string{}, errors.New("could not determine default plot type")
@@ -96,7 +368,7 @@ func defaultPlotTypes(splitGrouped map[string][]splitRes) ([]string, error) {
 func plotScatter(p plotter.Plotter, title, xName, yName string, splitGrouped map[string][]splitRes, includeLegend bool) error {
 	var (
 		xLabel = xName
-		yLabel = yName // TODO: include units
+		yLabel = yName
 	)
 
 	data, err := splitGroupedPlotData(splitGrouped)
@@ -110,7 +382,7 @@ func plotScatter(p plotter.Plotter, title, xName, yName string, splitGrouped map
 func plotAvgLine(p plotter.Plotter, title, xName, yName string, splitGrouped map[string][]splitRes, includeLegend bool) error {
 	var (
 		xLabel = xName
-		yLabel = yName // TODO: include units
+		yLabel = yName
 	)
 
 	data, err := splitGroupedAvgPlotData(splitGrouped)
@@ -120,6 +392,137 @@ func plotAvgLine(p plotter.Plotter, title, xName, yName string, splitGrouped map
 	return p.PlotLine(data, title, xLabel, yLabel, includeLegend)
 }
 
+// plotAggLine plots the benchmark results as a line plot where each
+// y value is the specified aggregate of the results at each x. The
+// name of each group states the aggregation.
+func plotAggLine(p plotter.Plotter, title, xName, yName string, splitGrouped map[string][]splitRes, agg string, includeLegend bool) error {
+	var (
+		xLabel = xName
+		yLabel = yName
+	)
+
+	aggData, err := splitGroupedAggPlotData(splitGrouped, agg)
+	if err != nil {
+		return err
+	}
+
+	data := make(map[string]plotter.NumericData, len(aggData))
+	for groupName, groupData := range aggData {
+		data[aggName(groupName, agg)] = groupData
+	}
+	return p.PlotLine(data, title, xLabel, yLabel, includeLegend)
+}
+
+// aggName returns the name of the group's aggregated line.
+func aggName(groupName, agg string) string {
+	if groupName == "" {
+		return agg
+	}
+	return fmt.Sprintf("%s (%s)", groupName, agg)
+}
+
+// plotBar plots the benchmark results as a bar chart where the height
+// of each bar is avg(f(x)).
+func plotBar(p plotter.Plotter, title, xName, yName string, splitGrouped map[string][]splitRes, includeLegend bool) error {
+	var (
+		xLabel = xName
+		yLabel = yName
+	)
+
+	data, err := splitGroupedCategoricalData(splitGrouped)
+	if err != nil {
+		return err
+	}
+	return p.PlotBar(data, title, xLabel, yLabel, includeLegend)
+}
+
+// plotAvgErrBar plots the benchmark results as a line where y(x) = avg(f(x)),
+// with error bars showing the spread of f(x).
+func plotAvgErrBar(p plotter.Plotter, title, xName, yName string, splitGrouped map[string][]splitRes, errKind string, includeLegend bool) error {
+	var (
+		xLabel = xName
+		yLabel = yName
+	)
+
+	data, err := splitGroupedErrorData(splitGrouped, errKind)
+	if err != nil {
+		return err
+	}
+	return p.PlotErrorBars(data, title, xLabel, yLabel, includeLegend)
+}
+
+// plotBox plots the benchmark results as a box plot of f(x) for
+// each x.
+func plotBox(p plotter.Plotter, title, xName, yName string, splitGrouped map[string][]splitRes, includeLegend bool) error {
+	var (
+		xLabel = xName
+		yLabel = yName
+	)
+
+	data, err := splitGroupedDistributionData(splitGrouped)
+	if err != nil {
+		return err
+	}
+	return p.PlotBox(data, title, xLabel, yLabel, includeLegend)
+}
+
+// plotFit plots the curve best fitting the benchmark results of each
+// group, and writes the model fit to each group if requested. The
+// legend is always included since it describes each fit.
+func plotFit(p plotter.Plotter, title, xName, yName string, splitGrouped map[string][]splitRes, xScale string, summary io.Writer) error {
+	var (
+		xLabel = xName
+		yLabel = yName
+	)
+
+	data, fits, err := splitGroupedFitData(splitGrouped, xScale)
+	if err != nil {
+		return err
+	}
+	if summary != nil {
+		if err := writeFitSummary(summary, title, fits); err != nil {
+			return fmt.Errorf("error writing summary: %w", err)
+		}
+	}
+	return p.PlotLine(data, title, xLabel, yLabel, true)
+}
+
+// plotScaling plots the speedup of each group relative to its results
+// with x = 1 along with the ideal linear speedup, and writes the speedup
+// and efficiency at each x if requested. The legend is always included
+// since it describes the efficiency of each group.
+func plotScaling(p plotter.Plotter, title, xName, yName string, splitGrouped map[string][]splitRes, summary io.Writer) error {
+	var (
+		xLabel = xName
+		yLabel = fmt.Sprintf("speedup over %s=1", xName)
+	)
+
+	scaling, err := splitGroupedScaling(splitGrouped, xName, yName)
+	if err != nil {
+		return err
+	}
+	if summary != nil {
+		if err := writeScalingSummary(summary, title, xName, scaling); err != nil {
+			return fmt.Errorf("error writing summary: %w", err)
+		}
+	}
+	return p.PlotLine(scalingPlotData(scaling, xName), title, xLabel, yLabel, true)
+}
+
+// plotOutliers plots the results discarded as outliers.
+func plotOutliers(p plotter.Plotter, title, xName, yName string, outliers map[string][]splitRes) error {
+	var (
+		xLabel = xName
+		yLabel = yName
+	)
+
+	data, err := splitGroupedPlotData(outliers)
+	if err != nil {
+		return err
+	}
+	return p.PlotOutliers(data, title, xLabel, yLabel)
+}
+
 func splitGroupedPlotData(splitGrouped map[string][]splitRes) (map[string]plotter.NumericData, error) {
 	data := map[string]plotter.NumericData{}
 	for groupName, splitResults := range splitGrouped {
@@ -150,59 +553,267 @@ func splitGroupedPlotData(splitGrouped map[string][]splitRes) (map[string]plotte
 }
 
 func splitGroupedAvgPlotData(splitGrouped map[string][]splitRes) (map[string]plotter.NumericData, error) {
+	return splitGroupedAggPlotData(splitGrouped, MeanAgg)
+}
+
+func splitGroupedAggPlotData(splitGrouped map[string][]splitRes, agg string) (map[string]plotter.NumericData, error) {
 	data := map[string]plotter.NumericData{}
 	for groupName, splitResults := range splitGrouped {
-		// track y values corresponding to each x
-		vals := map[float64][]float64{}
+		xData, yVals, err := valuesByX(splitResults)
+		if err != nil {
+			return nil, err
+		}
 
-		for _, res := range splitResults {
-			xF, err := getFloat(res.x)
+		yData := make([]float64, len(xData))
+		for i := range xData {
+			yData[i], err = aggregate(yVals[i], agg)
 			if err != nil {
-				return nil, fmt.Errorf("cannot create scatter plot from x data: %w", err)
+				return nil, err
 			}
+		}
 
-			yF, err := getFloat(res.y)
+		data[groupName] = plotter.NumericData{
+			X:           xData,
+			Y:           yData,
+			Aggregation: agg,
+		}
+	}
+	return data, nil
+}
+
+func splitGroupedErrorData(splitGrouped map[string][]splitRes, errKind string) (map[string]plotter.ErrorData, error) {
+	data := map[string]plotter.ErrorData{}
+	for groupName, splitResults := range splitGrouped {
+		xData, yVals, err := valuesByX(splitResults)
+		if err != nil {
+			return nil, err
+		}
+
+		var (
+			yData    = make([]float64, len(xData))
+			yErrLow  = make([]float64, len(xData))
+			yErrHigh = make([]float64, len(xData))
+		)
+		for i := range xData {
+			yData[i] = mean(yVals[i])
+			yErrLow[i], yErrHigh[i], err = yErr(yVals[i], errKind)
 			if err != nil {
-				return nil, fmt.Errorf("cannot create scatter plot from y data: %w", err)
+				return nil, err
+			}
+		}
+
+		data[groupName] = plotter.ErrorData{
+			X:        xData,
+			Y:        yData,
+			YErrLow:  yErrLow,
+			YErrHigh: yErrHigh,
+		}
+	}
+	return data, nil
+}
+
+// valuesByX returns the distinct x values of the results in
+// ascending order along with the y values corresponding to each x.
+func valuesByX(splitResults []splitRes) ([]float64, [][]float64, error) {
+	// track y values corresponding to each x
+	vals := map[float64][]float64{}
+
+	for _, res := range splitResults {
+		xF, err := getFloat(res.x)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot create scatter plot from x data: %w", err)
+		}
+
+		yF, err := getFloat(res.y)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot create scatter plot from y data: %w", err)
+		}
+
+		if xVals, ok := vals[xF]; ok {
+			vals[xF] = append(xVals, yF)
+		} else {
+			vals[xF] = []float64{yF}
+		}
+	}
+
+	var (
+		xData = make([]float64, len(vals))
+		yData = make([][]float64, len(vals))
+	)
+
+	i := 0
+	for x := range vals {
+		xData[i] = x
+		i++
+	}
+	// keep data sorted wrt x
+	sort.Float64s(xData)
+
+	for i, xVal := range xData {
+		yData[i] = vals[xVal]
+	}
+	return xData, yData, nil
+}
+
+func splitGroupedCategoricalData(splitGrouped map[string][]splitRes) (map[string]plotter.CategoricalData, error) {
+	// collect the distinct x values across all groups so every group
+	// uses the same category order
+	var (
+		categories = []interface{}{}
+		seen       = map[string]bool{}
+	)
+	for _, splitResults := range splitGrouped {
+		for _, res := range splitResults {
+			k := fmt.Sprint(res.x)
+			if !seen[k] {
+				seen[k] = true
+				categories = append(categories, res.x)
 			}
+		}
+	}
+	sortCategories(categories)
 
-			if xVals, ok := vals[xF]; ok {
-				vals[xF] = append(xVals, yF)
-			} else {
-				vals[xF] = []float64{yF}
+	data := map[string]plotter.CategoricalData{}
+	for groupName, splitResults := range splitGrouped {
+		// track y values corresponding to each x
+		vals := map[string][]float64{}
+
+		for _, res := range splitResults {
+			yF, err := getFloat(res.y)
+			if err != nil {
+				return nil, fmt.Errorf("cannot create bar plot from y data: %w", err)
 			}
+
+			k := fmt.Sprint(res.x)
+			vals[k] = append(vals[k], yF)
 		}
 
 		var (
-			xData = make([]float64, len(vals))
-			yData = make([]float64, len(vals))
+			xData = []string{}
+			yData = []float64{}
 		)
+		for _, category := range categories {
+			k := fmt.Sprint(category)
+			yVals, ok := vals[k]
+			if !ok {
+				continue
+			}
+			xData = append(xData, k)
+			yData = append(yData, mean(yVals))
+		}
 
-		i := 0
-		for x := range vals {
-			xData[i] = x
-			i++
+		data[groupName] = plotter.CategoricalData{
+			X: xData,
+			Y: yData,
 		}
-		// keep data sorted wrt x
-		sort.Float64s(xData)
+	}
+	return data, nil
+}
 
-		for i, xVal := range xData {
-			yVals := vals[xVal]
-			var totY float64 = 0
-			for _, yVal := range yVals {
-				totY += yVal
+func splitGroupedDistributionData(splitGrouped map[string][]splitRes) (map[string]plotter.DistributionData, error) {
+	var (
+		categories = []interface{}{}
+		seen       = map[string]bool{}
+		numeric    = true
+	)
+	for _, splitResults := range splitGrouped {
+		for _, res := range splitResults {
+			if _, err := getFloat(res.x); err != nil {
+				numeric = false
+			}
+			k := fmt.Sprint(res.x)
+			if !seen[k] {
+				seen[k] = true
+				categories = append(categories, res.x)
 			}
-			yData[i] = totY / float64(len(yVals))
 		}
+	}
 
-		data[groupName] = plotter.NumericData{
-			X: xData,
-			Y: yData,
+	data := map[string]plotter.DistributionData{}
+	if numeric {
+		for groupName, splitResults := range splitGrouped {
+			xData, yVals, err := valuesByX(splitResults)
+			if err != nil {
+				return nil, err
+			}
+			stats := make([]plotter.BoxStats, len(yVals))
+			for i, vals := range yVals {
+				stats[i] = boxStats(vals)
+			}
+			data[groupName] = plotter.DistributionData{
+				X:     xData,
+				Y:     yVals,
+				Stats: stats,
+			}
+		}
+		return data, nil
+	}
+
+	// non-numeric x values are positioned by their index
+	sortCategories(categories)
+	for groupName, splitResults := range splitGrouped {
+		// track y values corresponding to each x
+		vals := map[string][]float64{}
+
+		for _, res := range splitResults {
+			yF, err := getFloat(res.y)
+			if err != nil {
+				return nil, fmt.Errorf("cannot create box plot from y data: %w", err)
+			}
+
+			k := fmt.Sprint(res.x)
+			vals[k] = append(vals[k], yF)
+		}
+
+		var (
+			xData  = []float64{}
+			labels = []string{}
+			yData  = [][]float64{}
+			stats  = []plotter.BoxStats{}
+		)
+		for i, category := range categories {
+			k := fmt.Sprint(category)
+			yVals, ok := vals[k]
+			if !ok {
+				continue
+			}
+			xData = append(xData, float64(i))
+			labels = append(labels, k)
+			yData = append(yData, yVals)
+			stats = append(stats, boxStats(yVals))
+		}
+
+		data[groupName] = plotter.DistributionData{
+			X:      xData,
+			Labels: labels,
+			Y:      yData,
+			Stats:  stats,
 		}
 	}
 	return data, nil
 }
 
+// sortCategories sorts the categories numerically if they are all
+// numbers, otherwise by their string representation.
+func sortCategories(categories []interface{}) {
+	numeric := true
+	for _, category := range categories {
+		if _, err := getFloat(category); err != nil {
+			numeric = false
+			break
+		}
+	}
+
+	sort.Slice(categories, func(i, j int) bool {
+		if numeric {
+			iF, _ := getFloat(categories[i])
+			jF, _ := getFloat(categories[j])
+			return iF < jF
+		}
+		return fmt.Sprint(categories[i]) < fmt.Sprint(categories[j])
+	})
+}
+
 func getFloat(data interface{}) (float64, error) {
 	val := reflect.ValueOf(data)
 	switch val.Type().Kind() {
@@ -1,7 +1,9 @@
 package plot
 
 import (
+	"math"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/ShawnROGrady/benchparse"
@@ -28,16 +30,16 @@ var plotScatterTests = map[string]struct {
 		expectedData: map[string]plotter.NumericData{
 			"y=sin(x)": plotter.NumericData{
 				X: []float64{0.001, 0.01},
-				Y: []float64{2000, 200},
+				Y: []float64{2, 0.2},
 			},
 			"y=2x+3": plotter.NumericData{
 				X: []float64{0.001, 0.01},
-				Y: []float64{1000, 100},
+				Y: []float64{1, 0.1},
 			},
 		},
 		expectedTitle:  "BenchmarkMath",
 		expectedXLabel: "delta",
-		expectedYLabel: TimeName,
+		expectedYLabel: "time (µs/op)",
 	},
 	"x=int,y=int": {
 		benchmark: sampleBenchmark,
@@ -73,7 +75,7 @@ var plotScatterTests = map[string]struct {
 		},
 		expectedTitle:  "BenchmarkMath",
 		expectedXLabel: "delta",
-		expectedYLabel: NumAllocsName,
+		expectedYLabel: "mem_allocs (allocs/op)",
 	},
 	"x=float64,y=float64,valid_filter": {
 		benchmark: sampleBenchmark,
@@ -83,12 +85,12 @@ var plotScatterTests = map[string]struct {
 		expectedData: map[string]plotter.NumericData{
 			"": plotter.NumericData{
 				X: []float64{0.001, 0.01},
-				Y: []float64{2000, 200},
+				Y: []float64{2, 0.2},
 			},
 		},
 		expectedTitle:  "BenchmarkMath",
 		expectedXLabel: "delta",
-		expectedYLabel: TimeName,
+		expectedYLabel: "time (µs/op)",
 	},
 	"x=string,y=float64": {
 		benchmark: sampleBenchmark,
@@ -173,17 +175,19 @@ var plotAvgLineTests = map[string]struct {
 		xName:     "delta", yName: TimeName,
 		expectedData: map[string]plotter.NumericData{
 			"y=sin(x)": plotter.NumericData{
-				X: []float64{0.001, 0.01},
-				Y: []float64{2000, 200},
+				X:           []float64{0.001, 0.01},
+				Y:           []float64{2, 0.2},
+				Aggregation: MeanAgg,
 			},
 			"y=2x+3": plotter.NumericData{
-				X: []float64{0.001, 0.01},
-				Y: []float64{1000, 100},
+				X:           []float64{0.001, 0.01},
+				Y:           []float64{1, 0.1},
+				Aggregation: MeanAgg,
 			},
 		},
 		expectedTitle:  "BenchmarkMath",
 		expectedXLabel: "delta",
-		expectedYLabel: TimeName,
+		expectedYLabel: "time (µs/op)",
 	},
 	"x=float64,y=float64,valid_filter": {
 		benchmark: sampleBenchmark,
@@ -192,13 +196,14 @@ var plotAvgLineTests = map[string]struct {
 		xName:     "delta", yName: TimeName,
 		expectedData: map[string]plotter.NumericData{
 			"": plotter.NumericData{
-				X: []float64{0.001, 0.01},
-				Y: []float64{2000, 200},
+				X:           []float64{0.001, 0.01},
+				Y:           []float64{2, 0.2},
+				Aggregation: MeanAgg,
 			},
 		},
 		expectedTitle:  "BenchmarkMath",
 		expectedXLabel: "delta",
-		expectedYLabel: TimeName,
+		expectedYLabel: "time (µs/op)",
 	},
 	"x=int,y=int,2_dups": {
 		benchmark: sampleBenchmark,
@@ -206,12 +211,14 @@ var plotAvgLineTests = map[string]struct {
 		xName:     "start_x", yName: RunsName,
 		expectedData: map[string]plotter.NumericData{
 			"y=sin(x)": plotter.NumericData{
-				X: []float64{-2},
-				Y: []float64{55},
+				X:           []float64{-2},
+				Y:           []float64{55},
+				Aggregation: MeanAgg,
 			},
 			"y=2x+3": plotter.NumericData{
-				X: []float64{-2},
-				Y: []float64{7.5},
+				X:           []float64{-2},
+				Y:           []float64{7.5},
+				Aggregation: MeanAgg,
 			},
 		},
 		expectedTitle:  "BenchmarkMath",
@@ -224,8 +231,9 @@ var plotAvgLineTests = map[string]struct {
 		xName:     "start_x", yName: RunsName,
 		expectedData: map[string]plotter.NumericData{
 			"end_x=1": plotter.NumericData{
-				X: []float64{-2},
-				Y: []float64{31.25},
+				X:           []float64{-2},
+				Y:           []float64{31.25},
+				Aggregation: MeanAgg,
 			},
 		},
 		expectedTitle:  "BenchmarkMath",
@@ -238,8 +246,9 @@ var plotAvgLineTests = map[string]struct {
 		xName:     "delta", yName: RunsName,
 		expectedData: map[string]plotter.NumericData{
 			"end_x=1": plotter.NumericData{
-				X: []float64{0.001, 0.01},
-				Y: []float64{7.5, 55},
+				X:           []float64{0.001, 0.01},
+				Y:           []float64{7.5, 55},
+				Aggregation: MeanAgg,
 			},
 		},
 		expectedTitle:  "BenchmarkMath",
@@ -252,17 +261,19 @@ var plotAvgLineTests = map[string]struct {
 		xName:     "delta", yName: NumAllocsName,
 		expectedData: map[string]plotter.NumericData{
 			"y=sin(x)": plotter.NumericData{
-				X: []float64{0.001, 0.01},
-				Y: []float64{0, 0},
+				X:           []float64{0.001, 0.01},
+				Y:           []float64{0, 0},
+				Aggregation: MeanAgg,
 			},
 			"y=2x+3": plotter.NumericData{
-				X: []float64{0.001, 0.01},
-				Y: []float64{0, 0},
+				X:           []float64{0.001, 0.01},
+				Y:           []float64{0, 0},
+				Aggregation: MeanAgg,
 			},
 		},
 		expectedTitle:  "BenchmarkMath",
 		expectedXLabel: "delta",
-		expectedYLabel: NumAllocsName,
+		expectedYLabel: "mem_allocs (allocs/op)",
 	},
 	"x=string,y=float64": {
 		benchmark: sampleBenchmark,
@@ -329,6 +340,739 @@ func TestPlotAvgLine(t *testing.T) {
 	}
 }
 
+var plotBarTests = map[string]struct {
+	benchmark      benchparse.Benchmark
+	groupBy        []string
+	filterBy       []string
+	xName          string
+	yName          string
+	expectedData   map[string]plotter.CategoricalData
+	expectedTitle  string
+	expectedXLabel string
+	expectedYLabel string
+	expectErr      bool
+}{
+	"x=string,y=float64": {
+		benchmark: sampleBenchmark,
+		groupBy:   []string{"start_x"},
+		xName:     "y", yName: TimeName,
+		expectedData: map[string]plotter.CategoricalData{
+			"start_x=-2": plotter.CategoricalData{
+				X: []string{"2x+3", "sin(x)"},
+				Y: []float64{0.55, 1.1},
+			},
+		},
+		expectedTitle:  "BenchmarkMath",
+		expectedXLabel: "y",
+		expectedYLabel: "time (µs/op)",
+	},
+	"x=float64,y=float64": {
+		benchmark: sampleBenchmark,
+		groupBy:   []string{"y"},
+		xName:     "delta", yName: TimeName,
+		expectedData: map[string]plotter.CategoricalData{
+			"y=sin(x)": plotter.CategoricalData{
+				X: []string{"0.001", "0.01"},
+				Y: []float64{2, 0.2},
+			},
+			"y=2x+3": plotter.CategoricalData{
+				X: []string{"0.001", "0.01"},
+				Y: []float64{1, 0.1},
+			},
+		},
+		expectedTitle:  "BenchmarkMath",
+		expectedXLabel: "delta",
+		expectedYLabel: "time (µs/op)",
+	},
+	"x=string,y=int,valid_filter": {
+		benchmark: sampleBenchmark,
+		groupBy:   []string{},
+		filterBy:  []string{"delta==0.01"},
+		xName:     "y", yName: RunsName,
+		expectedData: map[string]plotter.CategoricalData{
+			"": plotter.CategoricalData{
+				X: []string{"2x+3", "sin(x)"},
+				Y: []float64{10, 100},
+			},
+		},
+		expectedTitle:  "BenchmarkMath",
+		expectedXLabel: "y",
+		expectedYLabel: RunsName,
+	},
+	"invalid_x_name": {
+		benchmark: sampleBenchmark,
+		groupBy:   []string{"start_x"},
+		xName:     "invalid_name", yName: TimeName,
+		expectErr: true,
+	},
+	"invalid_y_name": {
+		benchmark: sampleBenchmark,
+		groupBy:   []string{"start_x"},
+		xName:     "y", yName: "invalid_name",
+		expectErr: true,
+	},
+}
+
+func TestPlotBar(t *testing.T) {
+	for testName, testCase := range plotBarTests {
+		t.Run(testName, func(t *testing.T) {
+			p := &mock.Plotter{
+				PlotBarFn: func(data map[string]plotter.CategoricalData, title string, xLabel string, yLabel string, includeLegend bool) error {
+					// validate args
+					if !includeLegend {
+						t.Errorf("unexpectedly not including legend")
+					}
+					if !reflect.DeepEqual(data, testCase.expectedData) {
+						t.Errorf("unexpected plot data\nexpected:\n%v\nactual:\n%v", testCase.expectedData, data)
+					}
+					if title != testCase.expectedTitle {
+						t.Errorf("unexpected title\nexpected:\n%s\nactual:\n%s", testCase.expectedTitle, title)
+					}
+					if xLabel != testCase.expectedXLabel {
+						t.Errorf("unexpected xLabel\nexpected:\n%s\nactual:\n%s", testCase.expectedXLabel, xLabel)
+					}
+					if yLabel != testCase.expectedYLabel {
+						t.Errorf("unexpected yLabel\nexpected:\n%s\nactual:\n%s", testCase.expectedYLabel, yLabel)
+					}
+					return nil
+				},
+			}
+
+			opts := []plotOption{
+				WithGroupBy(testCase.groupBy),
+				WithPlotTypes([]string{BarType}),
+				WithFilterBy(testCase.filterBy),
+			}
+
+			err := Benchmark(testCase.benchmark, p, testCase.xName, testCase.yName, opts...)
+			if err != nil {
+				if !testCase.expectErr {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			if testCase.expectErr {
+				t.Error("unexpectedly no error")
+			}
+		})
+	}
+}
+
+var plotAvgErrBarTests = map[string]struct {
+	benchmark      benchparse.Benchmark
+	groupBy        []string
+	filterBy       []string
+	errKind        string
+	xName          string
+	yName          string
+	expectedData   map[string]plotter.ErrorData
+	expectedTitle  string
+	expectedXLabel string
+	expectedYLabel string
+	expectErr      bool
+}{
+	"x=float64,y=float64,no_dups": {
+		benchmark: sampleBenchmark,
+		groupBy:   []string{"y"},
+		errKind:   StdDevErr,
+		xName:     "delta", yName: TimeName,
+		expectedData: map[string]plotter.ErrorData{
+			"y=sin(x)": plotter.ErrorData{
+				X:        []float64{0.001, 0.01},
+				Y:        []float64{2, 0.2},
+				YErrLow:  []float64{0, 0},
+				YErrHigh: []float64{0, 0},
+			},
+			"y=2x+3": plotter.ErrorData{
+				X:        []float64{0.001, 0.01},
+				Y:        []float64{1, 0.1},
+				YErrLow:  []float64{0, 0},
+				YErrHigh: []float64{0, 0},
+			},
+		},
+		expectedTitle:  "BenchmarkMath",
+		expectedXLabel: "delta",
+		expectedYLabel: "time (µs/op)",
+	},
+	"x=float64,y=int,4_dups,stddev": {
+		benchmark: sampleBenchmark,
+		groupBy:   []string{"end_x"},
+		errKind:   StdDevErr,
+		xName:     "delta", yName: RunsName,
+		expectedData: map[string]plotter.ErrorData{
+			"end_x=1": plotter.ErrorData{
+				X:        []float64{0.001, 0.01},
+				Y:        []float64{7.5, 55},
+				YErrLow:  []float64{math.Sqrt(12.5), math.Sqrt(4050)},
+				YErrHigh: []float64{math.Sqrt(12.5), math.Sqrt(4050)},
+			},
+		},
+		expectedTitle:  "BenchmarkMath",
+		expectedXLabel: "delta",
+		expectedYLabel: RunsName,
+	},
+	"x=float64,y=int,4_dups,minmax": {
+		benchmark: sampleBenchmark,
+		groupBy:   []string{"end_x"},
+		errKind:   MinMaxErr,
+		xName:     "delta", yName: RunsName,
+		expectedData: map[string]plotter.ErrorData{
+			"end_x=1": plotter.ErrorData{
+				X:        []float64{0.001, 0.01},
+				Y:        []float64{7.5, 55},
+				YErrLow:  []float64{2.5, 45},
+				YErrHigh: []float64{2.5, 45},
+			},
+		},
+		expectedTitle:  "BenchmarkMath",
+		expectedXLabel: "delta",
+		expectedYLabel: RunsName,
+	},
+	"invalid_err_kind": {
+		benchmark: sampleBenchmark,
+		groupBy:   []string{"end_x"},
+		errKind:   "invalid",
+		xName:     "delta", yName: RunsName,
+		expectErr: true,
+	},
+	"x=string,y=float64": {
+		benchmark: sampleBenchmark,
+		groupBy:   []string{"start_x"},
+		errKind:   StdDevErr,
+		xName:     "y", yName: TimeName,
+		expectErr: true,
+	},
+}
+
+func TestPlotAvgErrBar(t *testing.T) {
+	for testName, testCase := range plotAvgErrBarTests {
+		t.Run(testName, func(t *testing.T) {
+			p := &mock.Plotter{
+				PlotErrorBarsFn: func(data map[string]plotter.ErrorData, title string, xLabel string, yLabel string, includeLegend bool) error {
+					// validate args
+					if !includeLegend {
+						t.Errorf("unexpectedly not including legend")
+					}
+					if !reflect.DeepEqual(data, testCase.expectedData) {
+						t.Errorf("unexpected plot data\nexpected:\n%v\nactual:\n%v", testCase.expectedData, data)
+					}
+					if title != testCase.expectedTitle {
+						t.Errorf("unexpected title\nexpected:\n%s\nactual:\n%s", testCase.expectedTitle, title)
+					}
+					if xLabel != testCase.expectedXLabel {
+						t.Errorf("unexpected xLabel\nexpected:\n%s\nactual:\n%s", testCase.expectedXLabel, xLabel)
+					}
+					if yLabel != testCase.expectedYLabel {
+						t.Errorf("unexpected yLabel\nexpected:\n%s\nactual:\n%s", testCase.expectedYLabel, yLabel)
+					}
+					return nil
+				},
+			}
+
+			opts := []plotOption{
+				WithGroupBy(testCase.groupBy),
+				WithPlotTypes([]string{AvgErrBarType}),
+				WithFilterBy(testCase.filterBy),
+				WithErrorKind(testCase.errKind),
+			}
+
+			err := Benchmark(testCase.benchmark, p, testCase.xName, testCase.yName, opts...)
+			if err != nil {
+				if !testCase.expectErr {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			if testCase.expectErr {
+				t.Error("unexpectedly no error")
+			}
+		})
+	}
+}
+
+var plotBoxTests = map[string]struct {
+	benchmark      benchparse.Benchmark
+	groupBy        []string
+	filterBy       []string
+	xName          string
+	yName          string
+	expectedData   map[string]plotter.DistributionData
+	expectedTitle  string
+	expectedXLabel string
+	expectedYLabel string
+	expectErr      bool
+}{
+	"x=float64,y=int,4_dups": {
+		benchmark: sampleBenchmark,
+		groupBy:   []string{"end_x"},
+		xName:     "delta", yName: RunsName,
+		expectedData: map[string]plotter.DistributionData{
+			"end_x=1": plotter.DistributionData{
+				X: []float64{0.001, 0.01},
+				Y: [][]float64{{10, 5}, {100, 10}},
+				Stats: []plotter.BoxStats{
+					{Min: 5, Max: 10, Q1: 6.25, Median: 7.5, Q3: 8.75, Low: 5, High: 10, Outliers: []float64{}},
+					{Min: 10, Max: 100, Q1: 32.5, Median: 55, Q3: 77.5, Low: 10, High: 100, Outliers: []float64{}},
+				},
+			},
+		},
+		expectedTitle:  "BenchmarkMath",
+		expectedXLabel: "delta",
+		expectedYLabel: RunsName,
+	},
+	"x=string,y=float64": {
+		benchmark: sampleBenchmark,
+		groupBy:   []string{"delta"},
+		xName:     "y", yName: TimeName,
+		expectedData: map[string]plotter.DistributionData{
+			"delta=0.001": plotter.DistributionData{
+				X:      []float64{0, 1},
+				Labels: []string{"2x+3", "sin(x)"},
+				Y:      [][]float64{{1}, {2}},
+				Stats:  []plotter.BoxStats{plotter.BoxStats{Min: 1, Max: 1, Q1: 1, Median: 1, Q3: 1, Low: 1, High: 1, Outliers: []float64{}}, plotter.BoxStats{Min: 2, Max: 2, Q1: 2, Median: 2, Q3: 2, Low: 2, High: 2, Outliers: []float64{}}},
+			},
+			"delta=0.01": plotter.DistributionData{
+				X:      []float64{0, 1},
+				Labels: []string{"2x+3", "sin(x)"},
+				Y:      [][]float64{{0.1}, {0.2}},
+				Stats:  []plotter.BoxStats{plotter.BoxStats{Min: 0.1, Max: 0.1, Q1: 0.1, Median: 0.1, Q3: 0.1, Low: 0.1, High: 0.1, Outliers: []float64{}}, plotter.BoxStats{Min: 0.2, Max: 0.2, Q1: 0.2, Median: 0.2, Q3: 0.2, Low: 0.2, High: 0.2, Outliers: []float64{}}},
+			},
+		},
+		expectedTitle:  "BenchmarkMath",
+		expectedXLabel: "y",
+		expectedYLabel: "time (µs/op)",
+	},
+	"x=string,y=float64,valid_filter": {
+		benchmark: sampleBenchmark,
+		groupBy:   []string{"delta"},
+		filterBy:  []string{"y==sin(x)"},
+		xName:     "y", yName: TimeName,
+		expectedData: map[string]plotter.DistributionData{
+			"delta=0.001": plotter.DistributionData{
+				X:      []float64{0},
+				Labels: []string{"sin(x)"},
+				Y:      [][]float64{{2}},
+				Stats:  []plotter.BoxStats{plotter.BoxStats{Min: 2, Max: 2, Q1: 2, Median: 2, Q3: 2, Low: 2, High: 2, Outliers: []float64{}}},
+			},
+			"delta=0.01": plotter.DistributionData{
+				X:      []float64{0},
+				Labels: []string{"sin(x)"},
+				Y:      [][]float64{{0.2}},
+				Stats:  []plotter.BoxStats{plotter.BoxStats{Min: 0.2, Max: 0.2, Q1: 0.2, Median: 0.2, Q3: 0.2, Low: 0.2, High: 0.2, Outliers: []float64{}}},
+			},
+		},
+		expectedTitle:  "BenchmarkMath",
+		expectedXLabel: "y",
+		expectedYLabel: "time (µs/op)",
+	},
+	"invalid_y_name": {
+		benchmark: sampleBenchmark,
+		groupBy:   []string{"start_x"},
+		xName:     "delta", yName: "invalid_name",
+		expectErr: true,
+	},
+}
+
+func TestPlotBox(t *testing.T) {
+	for testName, testCase := range plotBoxTests {
+		t.Run(testName, func(t *testing.T) {
+			p := &mock.Plotter{
+				PlotBoxFn: func(data map[string]plotter.DistributionData, title string, xLabel string, yLabel string, includeLegend bool) error {
+					// validate args
+					if !includeLegend {
+						t.Errorf("unexpectedly not including legend")
+					}
+					if !reflect.DeepEqual(data, testCase.expectedData) {
+						t.Errorf("unexpected plot data\nexpected:\n%v\nactual:\n%v", testCase.expectedData, data)
+					}
+					if title != testCase.expectedTitle {
+						t.Errorf("unexpected title\nexpected:\n%s\nactual:\n%s", testCase.expectedTitle, title)
+					}
+					if xLabel != testCase.expectedXLabel {
+						t.Errorf("unexpected xLabel\nexpected:\n%s\nactual:\n%s", testCase.expectedXLabel, xLabel)
+					}
+					if yLabel != testCase.expectedYLabel {
+						t.Errorf("unexpected yLabel\nexpected:\n%s\nactual:\n%s", testCase.expectedYLabel, yLabel)
+					}
+					return nil
+				},
+			}
+
+			opts := []plotOption{
+				WithGroupBy(testCase.groupBy),
+				WithPlotTypes([]string{BoxType}),
+				WithFilterBy(testCase.filterBy),
+			}
+
+			err := Benchmark(testCase.benchmark, p, testCase.xName, testCase.yName, opts...)
+			if err != nil {
+				if !testCase.expectErr {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			if testCase.expectErr {
+				t.Error("unexpectedly no error")
+			}
+		})
+	}
+}
+
+var compareTests = map[string]struct {
+	benches        []LabeledBenchmark
+	groupBy        []string
+	filterBy       []string
+	xName          string
+	yName          string
+	expectedData   map[string]plotter.NumericData
+	expectedTitle  string
+	expectedXLabel string
+	expectedYLabel string
+	expectErr      bool
+}{
+	"no_group_by": {
+		benches: []LabeledBenchmark{
+			{Label: "old.txt", Benchmark: sampleBenchmark},
+			{Label: "new.txt", Benchmark: benchparse.Benchmark{Name: "BenchmarkMath", Results: sampleBenchmark.Results[2:]}},
+		},
+		groupBy: []string{},
+		xName:   "delta", yName: TimeName,
+		expectedData: map[string]plotter.NumericData{
+			"old.txt": plotter.NumericData{
+				X: []float64{0.001, 0.01, 0.001, 0.01},
+				Y: []float64{2, 0.2, 1, 0.1},
+			},
+			"new.txt": plotter.NumericData{
+				X: []float64{0.001, 0.01},
+				Y: []float64{1, 0.1},
+			},
+		},
+		expectedTitle:  "BenchmarkMath",
+		expectedXLabel: "delta",
+		expectedYLabel: "time (µs/op)",
+	},
+	"with_group_by": {
+		benches: []LabeledBenchmark{
+			{Label: "old.txt", Benchmark: sampleBenchmark},
+			{Label: "new.txt", Benchmark: benchparse.Benchmark{Name: "BenchmarkMath", Results: sampleBenchmark.Results[2:]}},
+		},
+		groupBy: []string{"y"},
+		xName:   "delta", yName: TimeName,
+		expectedData: map[string]plotter.NumericData{
+			"old.txt,y=sin(x)": plotter.NumericData{
+				X: []float64{0.001, 0.01},
+				Y: []float64{2, 0.2},
+			},
+			"old.txt,y=2x+3": plotter.NumericData{
+				X: []float64{0.001, 0.01},
+				Y: []float64{1, 0.1},
+			},
+			"new.txt,y=2x+3": plotter.NumericData{
+				X: []float64{0.001, 0.01},
+				Y: []float64{1, 0.1},
+			},
+		},
+		expectedTitle:  "BenchmarkMath",
+		expectedXLabel: "delta",
+		expectedYLabel: "time (µs/op)",
+	},
+	"with_filter_by,different_names": {
+		benches: []LabeledBenchmark{
+			{Label: "old", Benchmark: sampleBenchmark},
+			{Label: "new", Benchmark: benchparse.Benchmark{Name: "BenchmarkOtherMath", Results: sampleBenchmark.Results}},
+		},
+		groupBy:  []string{},
+		filterBy: []string{"y==sin(x)"},
+		xName:    "delta", yName: TimeName,
+		expectedData: map[string]plotter.NumericData{
+			"old": plotter.NumericData{
+				X: []float64{0.001, 0.01},
+				Y: []float64{2, 0.2},
+			},
+			"new": plotter.NumericData{
+				X: []float64{0.001, 0.01},
+				Y: []float64{2, 0.2},
+			},
+		},
+		expectedTitle:  "BenchmarkMath, BenchmarkOtherMath",
+		expectedXLabel: "delta",
+		expectedYLabel: "time (µs/op)",
+	},
+	"invalid_x_name": {
+		benches: []LabeledBenchmark{
+			{Label: "old", Benchmark: sampleBenchmark},
+			{Label: "new", Benchmark: sampleBenchmark},
+		},
+		xName: "invalid_name", yName: TimeName,
+		expectErr: true,
+	},
+}
+
+func TestCompare(t *testing.T) {
+	for testName, testCase := range compareTests {
+		t.Run(testName, func(t *testing.T) {
+			p := &mock.Plotter{
+				PlotScatterFn: func(data map[string]plotter.NumericData, title string, xLabel string, yLabel string, includeLegend bool) error {
+					// validate args
+					if !includeLegend {
+						t.Errorf("unexpectedly not including legend")
+					}
+					if !reflect.DeepEqual(data, testCase.expectedData) {
+						t.Errorf("unexpected plot data\nexpected:\n%v\nactual:\n%v", testCase.expectedData, data)
+					}
+					if title != testCase.expectedTitle {
+						t.Errorf("unexpected title\nexpected:\n%s\nactual:\n%s", testCase.expectedTitle, title)
+					}
+					if xLabel != testCase.expectedXLabel {
+						t.Errorf("unexpected xLabel\nexpected:\n%s\nactual:\n%s", testCase.expectedXLabel, xLabel)
+					}
+					if yLabel != testCase.expectedYLabel {
+						t.Errorf("unexpected yLabel\nexpected:\n%s\nactual:\n%s", testCase.expectedYLabel, yLabel)
+					}
+					return nil
+				},
+			}
+
+			opts := []plotOption{
+				WithGroupBy(testCase.groupBy),
+				WithPlotTypes([]string{ScatterType}),
+				WithFilterBy(testCase.filterBy),
+			}
+
+			err := Compare(testCase.benches, p, testCase.xName, testCase.yName, opts...)
+			if err != nil {
+				if !testCase.expectErr {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			if testCase.expectErr {
+				t.Error("unexpectedly no error")
+			}
+		})
+	}
+}
+
+func TestBenchmarks(t *testing.T) {
+	var (
+		benches = []benchparse.Benchmark{
+			sampleBenchmark,
+			{Name: "BenchmarkOtherMath", Results: sampleBenchmark.Results[2:]},
+		}
+		expectedData = map[string]plotter.NumericData{
+			"BenchmarkMath,y=sin(x)": plotter.NumericData{
+				X:           []float64{0.001, 0.01},
+				Y:           []float64{2, 0.2},
+				Aggregation: MeanAgg,
+			},
+			"BenchmarkMath,y=2x+3": plotter.NumericData{
+				X:           []float64{0.001, 0.01},
+				Y:           []float64{1, 0.1},
+				Aggregation: MeanAgg,
+			},
+			"BenchmarkOtherMath,y=2x+3": plotter.NumericData{
+				X:           []float64{0.001, 0.01},
+				Y:           []float64{1, 0.1},
+				Aggregation: MeanAgg,
+			},
+		}
+		expectedTitle = "BenchmarkMath, BenchmarkOtherMath"
+	)
+
+	p := &mock.Plotter{
+		PlotLineFn: func(data map[string]plotter.NumericData, title string, xLabel string, yLabel string, includeLegend bool) error {
+			if !reflect.DeepEqual(data, expectedData) {
+				t.Errorf("unexpected plot data\nexpected:\n%v\nactual:\n%v", expectedData, data)
+			}
+			if title != expectedTitle {
+				t.Errorf("unexpected title\nexpected:\n%s\nactual:\n%s", expectedTitle, title)
+			}
+			return nil
+		},
+	}
+
+	opts := []plotOption{
+		WithGroupBy([]string{"y"}),
+		WithPlotTypes([]string{AvgLineType}),
+	}
+	if err := Benchmarks(benches, p, "delta", TimeName, opts...); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestPlotFitLegend(t *testing.T) {
+	called := false
+	p := &mock.Plotter{
+		PlotScatterFn: func(data map[string]plotter.NumericData, title string, xLabel string, yLabel string, includeLegend bool) error {
+			return nil
+		},
+		PlotLineFn: func(data map[string]plotter.NumericData, title string, xLabel string, yLabel string, includeLegend bool) error {
+			called = true
+			// the fit is described by the legend even when it is not the first plot
+			if !includeLegend {
+				t.Errorf("unexpectedly no legend for fit plot")
+			}
+			for name := range data {
+				if !strings.HasPrefix(name, "y=") || !strings.Contains(name, "R²=") {
+					t.Errorf("unexpected fit name: %s", name)
+				}
+			}
+			return nil
+		},
+	}
+
+	opts := []plotOption{
+		WithGroupBy([]string{"y"}),
+		WithPlotTypes([]string{ScatterType, FitType}),
+	}
+	if err := Benchmark(sampleBenchmark, p, "delta", TimeName, opts...); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !called {
+		t.Errorf("fit not plotted")
+	}
+}
+
+var setScalesTests = map[string]struct {
+	xScale         string
+	yScale         string
+	expectSet      bool
+	expectedXScale plotter.Scale
+	expectedYScale plotter.Scale
+	expectErr      bool
+}{
+	"no_scales": {
+		expectSet: false,
+	},
+	"log2_x": {
+		xScale:         "log2",
+		expectSet:      true,
+		expectedXScale: plotter.Log2Scale,
+		expectedYScale: plotter.LinearScale,
+	},
+	"log10_y": {
+		yScale:         "log10",
+		expectSet:      true,
+		expectedXScale: plotter.LinearScale,
+		expectedYScale: plotter.Log10Scale,
+	},
+	"both": {
+		xScale:         "log2",
+		yScale:         "linear",
+		expectSet:      true,
+		expectedXScale: plotter.Log2Scale,
+		expectedYScale: plotter.LinearScale,
+	},
+	"invalid_scale": {
+		xScale:    "log3",
+		expectErr: true,
+	},
+}
+
+func TestSetScales(t *testing.T) {
+	for testName, testCase := range setScalesTests {
+		t.Run(testName, func(t *testing.T) {
+			scalesSet := false
+			p := &mock.Plotter{
+				SetScalesFn: func(xScale plotter.Scale, yScale plotter.Scale) error {
+					scalesSet = true
+					if xScale != testCase.expectedXScale {
+						t.Errorf("unexpected x scale (expected=%s, actual=%s)", testCase.expectedXScale, xScale)
+					}
+					if yScale != testCase.expectedYScale {
+						t.Errorf("unexpected y scale (expected=%s, actual=%s)", testCase.expectedYScale, yScale)
+					}
+					return nil
+				},
+				PlotScatterFn: func(data map[string]plotter.NumericData, title string, xLabel string, yLabel string, includeLegend bool) error {
+					if testCase.expectSet && !scalesSet {
+						t.Errorf("scales not set before plotting")
+					}
+					return nil
+				},
+			}
+
+			opts := []plotOption{
+				WithPlotTypes([]string{ScatterType}),
+				WithXScale(testCase.xScale),
+				WithYScale(testCase.yScale),
+			}
+
+			err := Benchmark(sampleBenchmark, p, "delta", TimeName, opts...)
+			if err != nil {
+				if !testCase.expectErr {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			if testCase.expectErr {
+				t.Error("unexpectedly no error")
+			}
+			if scalesSet != testCase.expectSet {
+				t.Errorf("unexpected scales set (expected=%t, actual=%t)", testCase.expectSet, scalesSet)
+			}
+		})
+	}
+}
+
+var defaultPlotTypesTests = map[string]struct {
+	splitGrouped      map[string][]splitRes
+	expectedPlotTypes []string
+	expectErr         bool
+}{
+	"x=int": {
+		splitGrouped: map[string][]splitRes{
+			"": []splitRes{{x: 1, y: float64(100)}},
+		},
+		expectedPlotTypes: []string{ScatterType, AggLineType},
+	},
+	"x=float64": {
+		splitGrouped: map[string][]splitRes{
+			"": []splitRes{{x: 0.1, y: float64(100)}},
+		},
+		expectedPlotTypes: []string{ScatterType, AggLineType},
+	},
+	"x=string": {
+		splitGrouped: map[string][]splitRes{
+			"": []splitRes{{x: "quicksort", y: float64(100)}},
+		},
+		expectedPlotTypes: []string{BarType},
+	},
+	"x=bool": {
+		splitGrouped: map[string][]splitRes{
+			"": []splitRes{{x: true, y: float64(100)}},
+		},
+		expectedPlotTypes: []string{BarType},
+	},
+	"no_results": {
+		splitGrouped: map[string][]splitRes{
+			"": []splitRes{},
+		},
+		expectErr: true,
+	},
+}
+
+func TestDefaultPlotTypes(t *testing.T) {
+	for testName, testCase := range defaultPlotTypesTests {
+		t.Run(testName, func(t *testing.T) {
+			plotTypes, err := defaultPlotTypes(testCase.splitGrouped)
+			if err != nil {
+				if !testCase.expectErr {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			if testCase.expectErr {
+				t.Error("unexpectedly no error")
+			}
+
+			if !reflect.DeepEqual(plotTypes, testCase.expectedPlotTypes) {
+				t.Errorf("unexpected plot types\nexpected:\n%v\nactual:\n%v", testCase.expectedPlotTypes, plotTypes)
+			}
+		})
+	}
+}
+
 type plotFnInput struct {
 	data          map[string]plotter.NumericData
 	includeLegend bool
@@ -342,12 +1086,98 @@ var plotTests = map[string]struct {
 	groupBy              []string
 	filterBy             []string
 	plots                []string
+	aggregation          string
+	normalizeTo          string
+	yUnit                string
 	xName                string
 	yName                string
 	expectedScatterInput plotFnInput
 	expectedLineInput    plotFnInput
 	expectErr            bool
 }{
+	"x=float64,agg_line,median": {
+		benchmark:   sampleBenchmark,
+		groupBy:     []string{"y"},
+		aggregation: MedianAgg,
+		xName:       "delta", yName: TimeName,
+		expectedScatterInput: plotFnInput{
+			data: map[string]plotter.NumericData{
+				"y=sin(x)": plotter.NumericData{
+					X: []float64{0.001, 0.01},
+					Y: []float64{2, 0.2},
+				},
+				"y=2x+3": plotter.NumericData{
+					X: []float64{0.001, 0.01},
+					Y: []float64{1, 0.1},
+				},
+			},
+			title:         "BenchmarkMath",
+			xLabel:        "delta",
+			yLabel:        "time (µs/op)",
+			includeLegend: true,
+		},
+		expectedLineInput: plotFnInput{
+			data: map[string]plotter.NumericData{
+				"y=sin(x) (median)": plotter.NumericData{
+					X:           []float64{0.001, 0.01},
+					Y:           []float64{2, 0.2},
+					Aggregation: MedianAgg,
+				},
+				"y=2x+3 (median)": plotter.NumericData{
+					X:           []float64{0.001, 0.01},
+					Y:           []float64{1, 0.1},
+					Aggregation: MedianAgg,
+				},
+			},
+			title:         "BenchmarkMath",
+			xLabel:        "delta",
+			yLabel:        "time (µs/op)",
+			includeLegend: false,
+		},
+	},
+	"x=float64,agg_line,unknown_aggregation": {
+		benchmark:   sampleBenchmark,
+		plots:       []string{AggLineType},
+		aggregation: "average",
+		xName:       "delta", yName: TimeName,
+		expectErr: true,
+	},
+	"x=float64,scaling,no_baseline": {
+		benchmark: sampleBenchmark,
+		plots:     []string{ScalingType},
+		xName:     "delta", yName: TimeName,
+		expectErr: true,
+	},
+	"x=float64,scaling,normalized": {
+		benchmark:   sampleBenchmark,
+		groupBy:     []string{"y"},
+		plots:       []string{ScalingType},
+		normalizeTo: "y=true",
+		xName:       "delta", yName: TimeName,
+		expectErr: true,
+	},
+	"x=float64,avg_line,aggregation": {
+		benchmark:   sampleBenchmark,
+		plots:       []string{ScatterType, AvgLineType},
+		aggregation: MedianAgg,
+		xName:       "delta", yName: TimeName,
+		expectErr: true,
+	},
+	"x=float64,normalized,y_unit": {
+		benchmark:   sampleBenchmark,
+		groupBy:     []string{"y"},
+		plots:       []string{ScatterType},
+		normalizeTo: "y=true",
+		yUnit:       "ms/op",
+		xName:       "delta", yName: TimeName,
+		expectErr: true,
+	},
+	"x=float64,scaling+scatter": {
+		benchmark: sampleBenchmark,
+		plots:     []string{ScatterType, ScalingType},
+		xName:     "delta", yName: TimeName,
+		expectErr: true,
+	},
 	"x=float64,avg_line+scatter": {
 		benchmark: sampleBenchmark,
 		groupBy:   []string{"y"},
@@ -357,32 +1187,34 @@ var plotTests = map[string]struct {
 			data: map[string]plotter.NumericData{
 				"y=sin(x)": plotter.NumericData{
 					X: []float64{0.001, 0.01},
-					Y: []float64{2000, 200},
+					Y: []float64{2, 0.2},
 				},
 				"y=2x+3": plotter.NumericData{
 					X: []float64{0.001, 0.01},
-					Y: []float64{1000, 100},
+					Y: []float64{1, 0.1},
 				},
 			},
 			title:         "BenchmarkMath",
 			xLabel:        "delta",
-			yLabel:        TimeName,
+			yLabel:        "time (µs/op)",
 			includeLegend: true,
 		},
 		expectedLineInput: plotFnInput{
 			data: map[string]plotter.NumericData{
 				"y=sin(x)": plotter.NumericData{
-					X: []float64{0.001, 0.01},
-					Y: []float64{2000, 200},
+					X:           []float64{0.001, 0.01},
+					Y:           []float64{2, 0.2},
+					Aggregation: MeanAgg,
 				},
 				"y=2x+3": plotter.NumericData{
-					X: []float64{0.001, 0.01},
-					Y: []float64{1000, 100},
+					X:           []float64{0.001, 0.01},
+					Y:           []float64{1, 0.1},
+					Aggregation: MeanAgg,
 				},
 			},
 			title:         "BenchmarkMath",
 			xLabel:        "delta",
-			yLabel:        TimeName,
+			yLabel:        "time (µs/op)",
 			includeLegend: false,
 		},
 	},
@@ -394,32 +1226,34 @@ var plotTests = map[string]struct {
 			data: map[string]plotter.NumericData{
 				"y=sin(x)": plotter.NumericData{
 					X: []float64{0.001, 0.01},
-					Y: []float64{2000, 200},
+					Y: []float64{2, 0.2},
 				},
 				"y=2x+3": plotter.NumericData{
 					X: []float64{0.001, 0.01},
-					Y: []float64{1000, 100},
+					Y: []float64{1, 0.1},
 				},
 			},
 			title:         "BenchmarkMath",
 			xLabel:        "delta",
-			yLabel:        TimeName,
+			yLabel:        "time (µs/op)",
 			includeLegend: true,
 		},
 		expectedLineInput: plotFnInput{
 			data: map[string]plotter.NumericData{
-				"y=sin(x)": plotter.NumericData{
-					X: []float64{0.001, 0.01},
-					Y: []float64{2000, 200},
+				"y=sin(x) (mean)": plotter.NumericData{
+					X:           []float64{0.001, 0.01},
+					Y:           []float64{2, 0.2},
+					Aggregation: MeanAgg,
 				},
-				"y=2x+3": plotter.NumericData{
-					X: []float64{0.001, 0.01},
-					Y: []float64{1000, 100},
+				"y=2x+3 (mean)": plotter.NumericData{
+					X:           []float64{0.001, 0.01},
+					Y:           []float64{1, 0.1},
+					Aggregation: MeanAgg,
 				},
 			},
 			title:         "BenchmarkMath",
 			xLabel:        "delta",
-			yLabel:        TimeName,
+			yLabel:        "time (µs/op)",
 			includeLegend: false,
 		},
 	},
@@ -432,24 +1266,25 @@ var plotTests = map[string]struct {
 			data: map[string]plotter.NumericData{
 				"": plotter.NumericData{
 					X: []float64{0.001, 0.01},
-					Y: []float64{1000, 100},
+					Y: []float64{1, 0.1},
 				},
 			},
 			title:         "BenchmarkMath",
 			xLabel:        "delta",
-			yLabel:        TimeName,
+			yLabel:        "time (µs/op)",
 			includeLegend: true,
 		},
 		expectedLineInput: plotFnInput{
 			data: map[string]plotter.NumericData{
-				"": plotter.NumericData{
-					X: []float64{0.001, 0.01},
-					Y: []float64{1000, 100},
+				"mean": plotter.NumericData{
+					X:           []float64{0.001, 0.01},
+					Y:           []float64{1, 0.1},
+					Aggregation: MeanAgg,
 				},
 			},
 			title:         "BenchmarkMath",
 			xLabel:        "delta",
-			yLabel:        TimeName,
+			yLabel:        "time (µs/op)",
 			includeLegend: false,
 		},
 	},
@@ -517,6 +1352,9 @@ func TestPlot(t *testing.T) {
 				WithGroupBy(testCase.groupBy),
 				WithPlotTypes(testCase.plots),
 				WithFilterBy(testCase.filterBy),
+				WithAggregation(testCase.aggregation),
+				WithNormalizeTo(testCase.normalizeTo),
+				WithYUnit(testCase.yUnit),
 			}
 
 			err := Benchmark(testCase.benchmark, p, testCase.xName, testCase.yName, opts...)
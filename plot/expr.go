@@ -0,0 +1,141 @@
+package plot
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+
+	"github.com/ShawnROGrady/benchparse"
+)
+
+// ErrDivisionByZero indicates that evaluating a y expression
+// required dividing by zero.
+var ErrDivisionByZero = errors.New("division by zero")
+
+// isExpr reports whether the y name is an arithmetic expression rather
+// than the name of a single output.
+func isExpr(yName string) bool {
+	e, err := parser.ParseExpr(yName)
+	if err != nil {
+		// report the syntax error when evaluating
+		return true
+	}
+	_, isIdent := e.(*ast.Ident)
+	return !isIdent
+}
+
+// evalExpr evaluates the arithmetic expression for the result. The
+// identifiers of the expression are the names of outputs or of numeric
+// inputs, including positional components such as sub[0]. Custom
+// metrics whose units are not valid identifiers (e.g. "hits/op") may
+// be quoted.
+func evalExpr(b benchparse.BenchRes, expr string) (float64, error) {
+	e, err := parser.ParseExpr(expr)
+	if err != nil {
+		return 0, fmt.Errorf("invalid expression: %w", err)
+	}
+	return exprEvaluator{b: b, src: expr}.eval(e)
+}
+
+type exprEvaluator struct {
+	b   benchparse.BenchRes
+	src string
+}
+
+func (v exprEvaluator) eval(e ast.Expr) (float64, error) {
+	switch e := e.(type) {
+	case *ast.ParenExpr:
+		return v.eval(e.X)
+	case *ast.BasicLit:
+		switch e.Kind {
+		case token.INT, token.FLOAT:
+			return strconv.ParseFloat(e.Value, 64)
+		case token.STRING:
+			name, err := strconv.Unquote(e.Value)
+			if err != nil {
+				return 0, fmt.Errorf("invalid name %s: %w", e.Value, err)
+			}
+			return v.value(name)
+		}
+	case *ast.Ident:
+		return v.value(e.Name)
+	case *ast.IndexExpr:
+		// positional sub-benchmark components, e.g. sub[0]
+		if x, ok := e.X.(*ast.Ident); ok {
+			if i, ok := e.Index.(*ast.BasicLit); ok && i.Kind == token.INT {
+				return v.value(fmt.Sprintf("%s[%s]", x.Name, i.Value))
+			}
+		}
+	case *ast.UnaryExpr:
+		x, err := v.eval(e.X)
+		if err != nil {
+			return 0, err
+		}
+		switch e.Op {
+		case token.ADD:
+			return x, nil
+		case token.SUB:
+			return -x, nil
+		}
+	case *ast.BinaryExpr:
+		x, err := v.eval(e.X)
+		if err != nil {
+			return 0, err
+		}
+		y, err := v.eval(e.Y)
+		if err != nil {
+			return 0, err
+		}
+		switch e.Op {
+		case token.ADD:
+			return x + y, nil
+		case token.SUB:
+			return x - y, nil
+		case token.MUL:
+			return x * y, nil
+		case token.QUO:
+			if y == 0 {
+				return 0, fmt.Errorf("%w: '%s' is 0", ErrDivisionByZero, v.text(e.Y))
+			}
+			return x / y, nil
+		}
+	}
+	return 0, fmt.Errorf("unsupported expression: '%s' (only numbers, names, parentheses, and + - * / are allowed)", v.text(e))
+}
+
+// value returns the value of the named output, or if there is no such
+// output the value of the named input.
+func (v exprEvaluator) value(name string) (float64, error) {
+	if out, err := benchOutputValByName(v.b.Outputs, name); err == nil {
+		f, err := getFloat(out)
+		if err != nil {
+			return 0, fmt.Errorf("output '%s' is not numeric: %w", name, err)
+		}
+		return f, nil
+	}
+
+	names := outputNames(v.b.Outputs)
+	for _, varValue := range v.b.Inputs.VarValues {
+		if varValue.Name == name {
+			f, err := getFloat(varValue.Value)
+			if err != nil {
+				return 0, fmt.Errorf("input '%s' is not numeric: %w", name, err)
+			}
+			return f, nil
+		}
+		names = append(names, varValue.Name)
+	}
+	return 0, fmt.Errorf("unknown name: '%s' (options = %q)", name, names)
+}
+
+// text returns the source of the expression.
+func (v exprEvaluator) text(e ast.Expr) string {
+	start, end := int(e.Pos())-1, int(e.End())-1
+	if start < 0 || end > len(v.src) || start > end {
+		return ""
+	}
+	return v.src[start:end]
+}
@@ -0,0 +1,105 @@
+package plot
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ShawnROGrady/benchparse"
+)
+
+var exprTestRes = benchparse.BenchRes{
+	Inputs: benchparse.BenchInputs{
+		VarValues: []benchparse.BenchVarValue{
+			{Name: "n", Value: 100},
+			{Name: "zero", Value: 0},
+			{Name: "algo", Value: "quick"},
+			{Name: "sub[0]", Value: 8},
+		},
+	},
+	Outputs: newTestMetricOutputs(10, map[string]float64{"hits/op": 50}, withNsPerOp(2000), withAllocedBytesPerOp(64), withAllocsPerOp(4)),
+}
+
+var evalExprTests = map[string]struct {
+	expr          string
+	expected      float64
+	expectedErrIs error
+	expectedErr   string
+}{
+	"output_per_input":      {expr: "time / n", expected: 20},
+	"output_per_output":     {expr: "mem_used / mem_allocs", expected: 16},
+	"precedence":            {expr: "time - 2 * n / 4", expected: 1950},
+	"parentheses":           {expr: "(time - 1000) / (n * 2)", expected: 5},
+	"unary_minus":           {expr: "-runs + 1.5", expected: -8.5},
+	"sub_index":             {expr: "time / sub[0]", expected: 250},
+	"quoted_metric":         {expr: `"hits/op" / n`, expected: 0.5},
+	"division_by_zero":      {expr: "time / (n - n)", expectedErrIs: ErrDivisionByZero, expectedErr: "'(n - n)' is 0"},
+	"division_by_zero_name": {expr: "time / zero", expectedErrIs: ErrDivisionByZero, expectedErr: "'zero' is 0"},
+	"unknown_name":          {expr: "time / size", expectedErr: "unknown name: 'size'"},
+	"non_numeric_input":     {expr: "time / algo", expectedErr: "input 'algo' is not numeric"},
+	"unsupported_operator":  {expr: "time % n", expectedErr: "unsupported expression: 'time % n'"},
+	"invalid_syntax":        {expr: "time /", expectedErr: "invalid expression"},
+}
+
+func TestEvalExpr(t *testing.T) {
+	for testName, testCase := range evalExprTests {
+		t.Run(testName, func(t *testing.T) {
+			v, err := evalExpr(exprTestRes, testCase.expr)
+			if err != nil {
+				if testCase.expectedErr == "" {
+					t.Errorf("unexpected error: %s", err)
+				}
+				if !strings.Contains(err.Error(), testCase.expectedErr) {
+					t.Errorf("unexpected error (expected to contain = %q, actual = %s)", testCase.expectedErr, err)
+				}
+				if testCase.expectedErrIs != nil && !errors.Is(err, testCase.expectedErrIs) {
+					t.Errorf("unexpected error (expected = %s, actual = %s)", testCase.expectedErrIs, err)
+				}
+				return
+			}
+			if testCase.expectedErr != "" {
+				t.Fatalf("unexpectedly no error")
+			}
+
+			if v != testCase.expected {
+				t.Errorf("unexpected value (expected = %v, actual = %v)", testCase.expected, v)
+			}
+		})
+	}
+}
+
+func TestSplitBenchResExpr(t *testing.T) {
+	res, err := splitBenchRes(sampleBenchmark.Results[0], "delta", "time / end_x", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if res.y != float64(2000) {
+		t.Errorf("unexpected y value (expected = %v, actual = %v)", float64(2000), res.y)
+	}
+
+	// a custom metric containing an operator is not an expression
+	res, err = splitBenchRes(exprTestRes, "n", "hits/op", true)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if res.y != float64(50) {
+		t.Errorf("unexpected y value (expected = %v, actual = %v)", float64(50), res.y)
+	}
+}
+
+func TestSplitGroupedResultMetricNotMeasured(t *testing.T) {
+	missingRes := benchparse.BenchRes{
+		Inputs:  exprTestRes.Inputs,
+		Outputs: newTestMetricOutputs(10, map[string]float64{"misses/op": 5}, withNsPerOp(2000)),
+	}
+	grouped := benchparse.GroupedResults{
+		"algo=quick": {exprTestRes},
+		"algo=merge": {missingRes},
+	}
+
+	// the metric is not an expression since some results report it
+	_, err := splitGroupedResult(grouped, "n", "hits/op")
+	if !errors.Is(err, benchparse.ErrNotMeasured) {
+		t.Errorf("unexpected error (expected = %s, actual = %v)", benchparse.ErrNotMeasured, err)
+	}
+}
@@ -0,0 +1,196 @@
+package plot
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/ShawnROGrady/benchplot/plot/plotter"
+)
+
+// numFitPoints is the number of points used to draw a fitted curve.
+const numFitPoints = 50
+
+// complexityModel is a candidate model of how y grows with x, fit as
+// y = a + b * f(x) so that any fixed overhead is captured by a rather
+// than attributed to the growth of f(x).
+type complexityModel struct {
+	name string
+	f    func(x float64) float64
+}
+
+// complexityModels are the models considered when fitting data.
+var complexityModels = []complexityModel{
+	{name: "O(1)", f: func(x float64) float64 { return 1 }},
+	{name: "O(log n)", f: func(x float64) float64 { return math.Log2(x) }},
+	{name: "O(n)", f: func(x float64) float64 { return x }},
+	{name: "O(n log n)", f: func(x float64) float64 { return x * math.Log2(x) }},
+	{name: "O(n²)", f: func(x float64) float64 { return x * x }},
+}
+
+// complexityFit is the result of fitting data to a model.
+type complexityFit struct {
+	model     complexityModel
+	intercept float64
+	slope     float64
+	rSqr      float64
+	rss       float64
+}
+
+// fitComplexity fits the data to each model by least squares,
+// returning the model with the smallest residual sum of squares,
+// or the first such model if several fit equally well.
+// Models which are undefined for any x value are skipped.
+func fitComplexity(xs, ys []float64) (complexityFit, error) {
+	if len(xs) == 0 {
+		return complexityFit{}, errors.New("no data to fit")
+	}
+
+	var (
+		best  complexityFit
+		found = false
+	)
+	for _, model := range complexityModels {
+		fit, ok := fitModel(model, xs, ys)
+		if !ok {
+			continue
+		}
+		if !found || fit.rss < best.rss {
+			best, found = fit, true
+		}
+	}
+	if !found {
+		return complexityFit{}, errors.New("no model could be fit to the data")
+	}
+	return best, nil
+}
+
+// fitModel fits y = a + b * f(x) by ordinary least squares, reporting
+// whether the model could be fit. If f(x) is the same for every x the
+// slope is 0 and the intercept is the mean of the y values.
+func fitModel(model complexityModel, xs, ys []float64) (complexityFit, bool) {
+	fxs := make([]float64, len(xs))
+	for i, x := range xs {
+		fx := model.f(x)
+		if math.IsNaN(fx) || math.IsInf(fx, 0) {
+			return complexityFit{}, false
+		}
+		fxs[i] = fx
+	}
+
+	var (
+		mf, my   = mean(fxs), mean(ys)
+		sFY, sFF float64
+		fit      = complexityFit{model: model}
+		ssTot    float64
+	)
+	for i, fx := range fxs {
+		sFY += (fx - mf) * (ys[i] - my)
+		sFF += (fx - mf) * (fx - mf)
+	}
+	if sFF != 0 {
+		fit.slope = sFY / sFF
+	}
+	fit.intercept = my - fit.slope*mf
+
+	for i, fx := range fxs {
+		resid := ys[i] - (fit.intercept + fit.slope*fx)
+		fit.rss += resid * resid
+		ssTot += (ys[i] - my) * (ys[i] - my)
+	}
+	if ssTot == 0 {
+		fit.rSqr = 1
+		if fit.rss != 0 {
+			fit.rSqr = 0
+		}
+	} else {
+		fit.rSqr = 1 - fit.rss/ssTot
+	}
+	return fit, true
+}
+
+// writeFitSummary writes the model fit to each group, along with its
+// coefficients and how well it fits.
+func writeFitSummary(w io.Writer, title string, fits map[string]complexityFit) error {
+	// use sorted keys for consistent iteration order
+	groupNames := make([]string, len(fits))
+	j := 0
+	for k := range fits {
+		groupNames[j] = k
+		j++
+	}
+	sort.Strings(groupNames)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "%s (best fit of y = a + b*f(x))\n", title)
+	for _, groupName := range groupNames {
+		fit := fits[groupName]
+		if groupName != "" {
+			fmt.Fprintf(tw, "  %s\t", groupName)
+		} else {
+			fmt.Fprint(tw, "  ")
+		}
+		fmt.Fprintf(tw, "%s\ta=%.4g\tb=%.4g\tR²=%.3f\n", fit.model.name, fit.intercept, fit.slope, fit.rSqr)
+	}
+	return tw.Flush()
+}
+
+// fitCurve returns points along the fitted curve spanning the x values,
+// spaced evenly on the specified x scale.
+func fitCurve(fit complexityFit, xs []float64, xScale string) plotter.NumericData {
+	sorted := make([]float64, len(xs))
+	copy(sorted, xs)
+	sort.Float64s(sorted)
+
+	var (
+		min, max = sorted[0], sorted[len(sorted)-1]
+		logScale = xScale == string(plotter.Log2Scale) || xScale == string(plotter.Log10Scale)
+		curve    = plotter.NumericData{X: make([]float64, numFitPoints), Y: make([]float64, numFitPoints)}
+	)
+	for i := 0; i < numFitPoints; i++ {
+		frac := float64(i) / float64(numFitPoints-1)
+		x := min + frac*(max-min)
+		if logScale && min > 0 {
+			x = min * math.Pow(max/min, frac)
+		}
+		curve.X[i] = x
+		curve.Y[i] = fit.intercept + fit.slope*fit.model.f(x)
+	}
+	return curve
+}
+
+// fitName returns the name of the fitted curve of the group, which
+// describes the model and how well it fits.
+func fitName(groupName string, fit complexityFit) string {
+	desc := fmt.Sprintf("%s, R²=%.2f", fit.model.name, fit.rSqr)
+	if groupName == "" {
+		return desc
+	}
+	return fmt.Sprintf("%s (%s)", groupName, desc)
+}
+
+// splitGroupedFitData fits the data of each group, returning the fitted
+// curve of each group keyed by its fitName and the fit of each group.
+func splitGroupedFitData(splitGrouped map[string][]splitRes, xScale string) (map[string]plotter.NumericData, map[string]complexityFit, error) {
+	raw, err := splitGroupedPlotData(splitGrouped)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		data = make(map[string]plotter.NumericData, len(raw))
+		fits = make(map[string]complexityFit, len(raw))
+	)
+	for groupName, groupData := range raw {
+		fit, err := fitComplexity(groupData.X, groupData.Y)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error fitting %s: %w", groupName, err)
+		}
+		data[fitName(groupName, fit)] = fitCurve(fit, groupData.X, xScale)
+		fits[groupName] = fit
+	}
+	return data, fits, nil
+}
@@ -0,0 +1,170 @@
+package plot
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/ShawnROGrady/benchplot/plot/plotter"
+)
+
+var fitComplexityTests = map[string]struct {
+	xs            []float64
+	f             func(x float64) float64
+	expectedModel string
+	expectedCoefs []float64 // the intercept and slope, if checked
+	expectErr     bool
+}{
+	"constant": {
+		xs:            []float64{1, 10, 100, 1000},
+		f:             func(x float64) float64 { return 5 },
+		expectedModel: "O(1)",
+	},
+	"logarithmic": {
+		xs:            []float64{2, 16, 128, 1024},
+		f:             func(x float64) float64 { return 3 * math.Log2(x) },
+		expectedModel: "O(log n)",
+	},
+	"linear": {
+		xs:            []float64{1, 10, 100, 1000},
+		f:             func(x float64) float64 { return 2 * x },
+		expectedModel: "O(n)",
+	},
+	"linearithmic": {
+		xs:            []float64{2, 16, 128, 1024},
+		f:             func(x float64) float64 { return 0.5 * x * math.Log2(x) },
+		expectedModel: "O(n log n)",
+	},
+	"quadratic": {
+		xs:            []float64{1, 10, 100, 1000},
+		f:             func(x float64) float64 { return x * x / 10 },
+		expectedModel: "O(n²)",
+	},
+	"linear,overhead": {
+		xs:            []float64{1, 10, 100, 1000},
+		f:             func(x float64) float64 { return 500 + 2*x },
+		expectedModel: "O(n)",
+		expectedCoefs: []float64{500, 2},
+	},
+	"logarithmic,overhead": {
+		xs:            []float64{2, 16, 128, 1024},
+		f:             func(x float64) float64 { return 1000 + 3*math.Log2(x) },
+		expectedModel: "O(log n)",
+	},
+	"linearithmic,overhead": {
+		xs:            []float64{2, 16, 128, 1024},
+		f:             func(x float64) float64 { return 2000 + 0.5*x*math.Log2(x) },
+		expectedModel: "O(n log n)",
+	},
+	"single_x": {
+		xs:            []float64{8, 8, 8},
+		f:             func(x float64) float64 { return 7 },
+		expectedModel: "O(1)",
+	},
+	"zero_x_skips_log_models": {
+		xs:            []float64{0, 10, 20, 30},
+		f:             func(x float64) float64 { return 4*x + 1 },
+		expectedModel: "O(n)",
+	},
+	"no_data": {
+		xs:        []float64{},
+		f:         func(x float64) float64 { return x },
+		expectErr: true,
+	},
+}
+
+func TestFitComplexity(t *testing.T) {
+	for testName, testCase := range fitComplexityTests {
+		t.Run(testName, func(t *testing.T) {
+			ys := make([]float64, len(testCase.xs))
+			for i, x := range testCase.xs {
+				ys[i] = testCase.f(x)
+			}
+
+			fit, err := fitComplexity(testCase.xs, ys)
+			if err != nil {
+				if !testCase.expectErr {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			if testCase.expectErr {
+				t.Fatalf("unexpectedly no error")
+			}
+
+			if fit.model.name != testCase.expectedModel {
+				t.Errorf("unexpected model (expected = %s, actual = %s)", testCase.expectedModel, fit.model.name)
+			}
+			if fit.rSqr < 0.99 {
+				t.Errorf("unexpectedly poor fit (R² = %v)", fit.rSqr)
+			}
+			if testCase.expectedCoefs != nil {
+				if math.Abs(fit.intercept-testCase.expectedCoefs[0]) > 1e-6 || math.Abs(fit.slope-testCase.expectedCoefs[1]) > 1e-6 {
+					t.Errorf("unexpected coefficients (expected = %v, actual = [%v %v])", testCase.expectedCoefs, fit.intercept, fit.slope)
+				}
+			}
+		})
+	}
+}
+
+func TestSplitGroupedFitData(t *testing.T) {
+	splitGrouped := map[string][]splitRes{
+		"impl=a": {{x: 1, y: 2.0}, {x: 2, y: 4.0}, {x: 4, y: 8.0}},
+		"":       {{x: 1, y: 3.0}, {x: 2, y: 3.0}, {x: 4, y: 3.0}},
+	}
+
+	data, fits, err := splitGroupedFitData(splitGrouped, string(plotter.Log2Scale))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// curves are named after their group and fit, so they sort in the
+	// same order as the group's other plots
+	expectedModels := map[string]string{"impl=a": "O(n)", "": "O(1)"}
+	expectedNames := map[string]string{"impl=a": "impl=a (O(n), R²=1.00)", "": "O(1), R²=1.00"}
+	if len(data) != len(expectedModels) || len(fits) != len(expectedModels) {
+		t.Fatalf("unexpected number of curves (expected = %d, actual = %d, %d)", len(expectedModels), len(data), len(fits))
+	}
+	for groupName, model := range expectedModels {
+		curve, ok := data[expectedNames[groupName]]
+		if !ok {
+			t.Errorf("missing curve %q in %v", expectedNames[groupName], data)
+			continue
+		}
+		if len(curve.X) != numFitPoints || curve.X[0] != 1 || curve.X[numFitPoints-1] != 4 {
+			t.Errorf("unexpected x values for %q: %v", groupName, curve.X)
+		}
+		if curve.Aggregation != "" {
+			t.Errorf("unexpected aggregation for %q: %s", groupName, curve.Aggregation)
+		}
+		if fits[groupName].model.name != model {
+			t.Errorf("unexpected model for %q (expected = %s, actual = %s)", groupName, model, fits[groupName].model.name)
+		}
+	}
+
+	// points are spaced evenly on the log scale
+	curve := data[expectedNames["impl=a"]]
+	if mid := curve.X[(numFitPoints-1)/2]; mid >= 2.2 {
+		t.Errorf("expected geometrically spaced x values, got midpoint %v", mid)
+	}
+}
+
+func TestWriteFitSummary(t *testing.T) {
+	var (
+		fits = map[string]complexityFit{
+			"impl=b": {model: complexityModels[0], intercept: 3, rSqr: 1},
+			"impl=a": {model: complexityModels[2], intercept: 120, slope: 2.5, rSqr: 0.9876},
+		}
+		buf bytes.Buffer
+	)
+	if err := writeFitSummary(&buf, "BenchmarkSort", fits); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := "BenchmarkSort (best fit of y = a + b*f(x))\n" +
+		"  impl=a  O(n)  a=120  b=2.5  R²=0.988\n" +
+		"  impl=b  O(1)  a=3    b=0    R²=1.000\n"
+	if buf.String() != expected {
+		t.Errorf("unexpected summary\nexpected:\n%s\nactual:\n%s", expected, buf.String())
+	}
+}
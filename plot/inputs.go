@@ -0,0 +1,139 @@
+package plot
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ShawnROGrady/benchparse"
+	"github.com/ShawnROGrady/benchplot/input"
+)
+
+// ProcsName is the name of the input variable holding the value of
+// GOMAXPROCS when the benchmark was run, which is the suffix of the
+// benchmark name (e.g. 8 for BenchmarkFoo-8).
+const ProcsName = "procs"
+
+// subVarName returns the name of the variable holding the i-th component
+// of a sub-benchmark name which is not of the form var_name=var_value.
+func subVarName(i int) string {
+	return fmt.Sprintf("sub[%d]", i)
+}
+
+// deriveInputs returns copies of the results with additional input
+// variables: one for each positional sub-benchmark component (e.g.
+// "sub[0]"), one for GOMAXPROCS and each metadata key (e.g. "goarch")
+// if known and not already a variable, and if pattern is non-nil one
+// for each of its named captures within the sub-benchmark name.
+// Derived values other than metadata are typed like those of
+// var_name=var_value components, and a capture replaces any existing
+// variable with the same name.
+func deriveInputs(results benchparse.BenchResults, pattern *regexp.Regexp) benchparse.BenchResults {
+	derived := make(benchparse.BenchResults, len(results))
+	for i, res := range results {
+		varValues := make([]benchparse.BenchVarValue, len(res.Inputs.VarValues), len(res.Inputs.VarValues)+len(res.Inputs.Subs)+1)
+		copy(varValues, res.Inputs.VarValues)
+		for j, sub := range res.Inputs.Subs {
+			varValues = append(varValues, benchparse.BenchVarValue{Name: subVarName(j), Value: input.ParseValue(sub.Name)})
+		}
+		if res.Inputs.MaxProcs > 0 && !hasVarValue(varValues, ProcsName) {
+			varValues = append(varValues, benchparse.BenchVarValue{Name: ProcsName, Value: res.Inputs.MaxProcs})
+		}
+		if m, ok := res.Outputs.(metadataOutputs); ok {
+			for _, key := range m.MetadataKeys() {
+				if v, _ := m.GetMetadata(key); !hasVarValue(varValues, key) {
+					varValues = append(varValues, benchparse.BenchVarValue{Name: key, Value: v})
+				}
+			}
+		}
+
+		if pattern != nil {
+			if match := pattern.FindStringSubmatch(subName(res.Inputs)); match != nil {
+				for k, name := range pattern.SubexpNames() {
+					if name == "" {
+						continue
+					}
+					varValues = setVarValue(varValues, benchparse.BenchVarValue{Name: name, Value: input.ParseValue(match[k])})
+				}
+			}
+		}
+
+		res.Inputs.VarValues = varValues
+		derived[i] = res
+	}
+	return derived
+}
+
+// metadataOutputs are benchmark outputs which include the
+// configuration the benchmark was run with, such as input.Outputs.
+type metadataOutputs interface {
+	GetMetadata(key string) (string, bool)
+	MetadataKeys() []string
+}
+
+// metadataSubtitle describes the configuration the results were run
+// with, listing the distinct values of each metadata key in the order
+// they are first seen (e.g. "goos: linux; goarch: amd64, arm64").
+func metadataSubtitle(results []benchparse.BenchResults) string {
+	var (
+		keys   = []string{}
+		values = map[string][]string{}
+	)
+	for _, res := range results {
+		for _, r := range res {
+			m, ok := r.Outputs.(metadataOutputs)
+			if !ok {
+				continue
+			}
+			for _, key := range m.MetadataKeys() {
+				v, _ := m.GetMetadata(key)
+				if _, ok := values[key]; !ok {
+					keys = append(keys, key)
+				}
+				if !containsString(values[key], v) {
+					values[key] = append(values[key], v)
+				}
+			}
+		}
+	}
+
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		parts[i] = fmt.Sprintf("%s: %s", key, strings.Join(values[key], ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// subName returns the name of the sub-benchmark following the name of
+// the top-level benchmark, without the leading "/" or GOMAXPROCS suffix
+// (e.g. "1024/parallel").
+func subName(inputs benchparse.BenchInputs) string {
+	name := strings.TrimPrefix(inputs.String(), "/")
+	if inputs.MaxProcs > 1 {
+		name = strings.TrimSuffix(name, "-"+strconv.Itoa(inputs.MaxProcs))
+	}
+	return name
+}
+
+// hasVarValue reports whether there is a variable with the name.
+func hasVarValue(varValues []benchparse.BenchVarValue, name string) bool {
+	for _, v := range varValues {
+		if v.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// setVarValue replaces the variable with the same name as v, or adds v
+// if there is no such variable.
+func setVarValue(varValues []benchparse.BenchVarValue, v benchparse.BenchVarValue) []benchparse.BenchVarValue {
+	for i, existing := range varValues {
+		if existing.Name == v.Name {
+			varValues[i] = v
+			return varValues
+		}
+	}
+	return append(varValues, v)
+}
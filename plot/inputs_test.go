@@ -0,0 +1,133 @@
+package plot
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/ShawnROGrady/benchparse"
+	"github.com/ShawnROGrady/benchplot/input"
+)
+
+var deriveInputsTests = map[string]struct {
+	inputs            benchparse.BenchInputs
+	outputs           benchparse.BenchOutputs
+	pattern           *regexp.Regexp
+	expectedVarValues []benchparse.BenchVarValue
+}{
+	"positional": {
+		inputs: benchparse.BenchInputs{
+			VarValues: []benchparse.BenchVarValue{{Name: "n", Value: 2}},
+			Subs:      []benchparse.BenchSub{{Name: "1024"}, {Name: "parallel"}},
+		},
+		expectedVarValues: []benchparse.BenchVarValue{
+			{Name: "n", Value: 2},
+			{Name: "sub[0]", Value: 1024},
+			{Name: "sub[1]", Value: "parallel"},
+		},
+	},
+	"pattern": {
+		inputs: benchparse.BenchInputs{
+			Subs:     []benchparse.BenchSub{{Name: "1.5KB"}},
+			MaxProcs: 8,
+		},
+		pattern: regexp.MustCompile(`^(?P<size>[\d.]+)(?P<unit>[A-Z]+)$`),
+		expectedVarValues: []benchparse.BenchVarValue{
+			{Name: "sub[0]", Value: "1.5KB"},
+			{Name: ProcsName, Value: 8},
+			{Name: "size", Value: 1.5},
+			{Name: "unit", Value: "KB"},
+		},
+	},
+	"procs": {
+		inputs: benchparse.BenchInputs{
+			VarValues: []benchparse.BenchVarValue{{Name: "n", Value: 2}},
+			MaxProcs:  4,
+		},
+		expectedVarValues: []benchparse.BenchVarValue{
+			{Name: "n", Value: 2},
+			{Name: ProcsName, Value: 4},
+		},
+	},
+	"procs_already_input": {
+		inputs: benchparse.BenchInputs{
+			VarValues: []benchparse.BenchVarValue{{Name: ProcsName, Value: 16}},
+			MaxProcs:  4,
+		},
+		expectedVarValues: []benchparse.BenchVarValue{
+			{Name: ProcsName, Value: 16},
+		},
+	},
+	"metadata": {
+		inputs: benchparse.BenchInputs{
+			VarValues: []benchparse.BenchVarValue{{Name: "n", Value: 10}},
+		},
+		outputs: input.Outputs{Metadata: map[string]string{"goarch": "arm64", "goos": "linux"}},
+		expectedVarValues: []benchparse.BenchVarValue{
+			{Name: "n", Value: 10},
+			{Name: "goos", Value: "linux"},
+			{Name: "goarch", Value: "arm64"},
+		},
+	},
+	"metadata_already_input": {
+		inputs: benchparse.BenchInputs{
+			VarValues: []benchparse.BenchVarValue{{Name: "cpu", Value: 4}},
+		},
+		outputs: input.Outputs{Metadata: map[string]string{"cpu": "Apple M1"}},
+		expectedVarValues: []benchparse.BenchVarValue{
+			{Name: "cpu", Value: 4},
+		},
+	},
+	"pattern_replaces_existing": {
+		inputs: benchparse.BenchInputs{
+			Subs: []benchparse.BenchSub{{Name: "true"}},
+		},
+		pattern: regexp.MustCompile(`^(?P<sub>\w+)$`),
+		expectedVarValues: []benchparse.BenchVarValue{
+			{Name: "sub[0]", Value: true},
+			{Name: "sub", Value: true},
+		},
+	},
+	"pattern_not_matched": {
+		inputs: benchparse.BenchInputs{
+			Subs: []benchparse.BenchSub{{Name: "fast"}},
+		},
+		pattern: regexp.MustCompile(`^(?P<size>\d+)$`),
+		expectedVarValues: []benchparse.BenchVarValue{
+			{Name: "sub[0]", Value: "fast"},
+		},
+	},
+}
+
+func TestDeriveInputs(t *testing.T) {
+	for testName, testCase := range deriveInputsTests {
+		t.Run(testName, func(t *testing.T) {
+			results := benchparse.BenchResults{{Inputs: testCase.inputs, Outputs: testCase.outputs}}
+			derived := deriveInputs(results, testCase.pattern)
+
+			if !reflect.DeepEqual(derived[0].Inputs.VarValues, testCase.expectedVarValues) {
+				t.Errorf("unexpected var values\nexpected:\n%v\nactual:\n%v", testCase.expectedVarValues, derived[0].Inputs.VarValues)
+			}
+			if len(results[0].Inputs.VarValues) != len(testCase.inputs.VarValues) {
+				t.Errorf("original results unexpectedly modified: %v", results[0].Inputs.VarValues)
+			}
+		})
+	}
+}
+
+func TestMetadataSubtitle(t *testing.T) {
+	var (
+		amd64 = input.Outputs{Metadata: map[string]string{"goos": "linux", "goarch": "amd64", "pkg": "example/cache"}}
+		arm64 = input.Outputs{Metadata: map[string]string{"goos": "linux", "goarch": "arm64", "pkg": "example/cache"}}
+	)
+	results := []benchparse.BenchResults{
+		{{Outputs: amd64}, {Outputs: amd64}},
+		{{Outputs: arm64}},
+		{{Outputs: nil}},
+	}
+
+	expected := "goos: linux; goarch: amd64, arm64; pkg: example/cache"
+	if subtitle := metadataSubtitle(results); subtitle != expected {
+		t.Errorf("unexpected subtitle (expected=%q, actual=%q)", expected, subtitle)
+	}
+}
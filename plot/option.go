@@ -1,16 +1,30 @@
 package plot
 
+import "io"
+
 type plotOption interface {
 	apply(*plotOptions)
 }
 
-// WithPlotTypes is an option to specify the plots to create.
+// WithPlotTypes is an option to specify the plots to create. If empty
+// numeric data is plotted as "scatter" and "agg_line" plots (which
+// previously defaulted to "avg_line" rather than "agg_line"), and
+// non-numeric data as a "bar" plot.
 type WithPlotTypes []string
 
 func (w WithPlotTypes) apply(p *plotOptions) {
 	p.plotTypes = []string(w)
 }
 
+// WithAggregation is an option to specify how the y values at each x
+// are aggregated for "agg_line" plots, including the default plots of
+// numeric data (e.g. "median" or "p90"). If empty "mean" is used.
+type WithAggregation string
+
+func (w WithAggregation) apply(p *plotOptions) {
+	p.aggregation = string(w)
+}
+
 // WithGroupBy is an option to specify how plot data should be grouped.
 type WithGroupBy []string
 
@@ -25,3 +39,103 @@ type WithFilterBy []string
 func (w WithFilterBy) apply(p *plotOptions) {
 	p.filterExprs = []string(w)
 }
+
+// WithNamePattern is an option to specify a regular expression with
+// named captures (e.g. `^(?P<size>\d+)/(?P<mode>\w+)$`) matched against
+// the sub-benchmark name of each result, such that each capture is an
+// input variable which can be plotted, grouped, and filtered by.
+type WithNamePattern string
+
+func (w WithNamePattern) apply(p *plotOptions) {
+	p.namePattern = string(w)
+}
+
+// WithErrorKind is an option to specify the kind of error
+// to display for plots with error bars.
+type WithErrorKind string
+
+func (w WithErrorKind) apply(p *plotOptions) {
+	p.errKind = string(w)
+}
+
+// WithNormalizeTo is an option to specify a baseline group to
+// normalize the data to, such that each y value is relative to
+// the baseline's average y value at the same x.
+type WithNormalizeTo string
+
+func (w WithNormalizeTo) apply(p *plotOptions) {
+	p.normalizeTo = string(w)
+}
+
+// WithYUnit is an option to specify the unit to display the
+// y values in (e.g. "ms/op"). If not specified a unit is selected
+// based on the magnitude of the data.
+type WithYUnit string
+
+func (w WithYUnit) apply(p *plotOptions) {
+	p.yUnit = string(w)
+}
+
+// WithXScale is an option to specify the scale of the x-axis
+// (e.g. "log2").
+type WithXScale string
+
+func (w WithXScale) apply(p *plotOptions) {
+	p.xScale = string(w)
+}
+
+// WithYScale is an option to specify the scale of the y-axis
+// (e.g. "log10").
+type WithYScale string
+
+func (w WithYScale) apply(p *plotOptions) {
+	p.yScale = string(w)
+}
+
+// WithSignificance is an option to test whether the difference of
+// each group from its baseline is significant at the specified level
+// (e.g. 0.05), marking each compared point accordingly.
+type WithSignificance float64
+
+func (w WithSignificance) apply(p *plotOptions) {
+	p.alpha = float64(w)
+}
+
+// WithCompareTo is an option to specify the baseline group (e.g.
+// 'impl=naive') or label of compared benchmarks to test significance
+// against. If not specified the normalization baseline is used,
+// otherwise the first labeled benchmark, or if there are exactly two
+// groups the first is the baseline.
+type WithCompareTo string
+
+func (w WithCompareTo) apply(p *plotOptions) {
+	p.compareTo = string(w)
+}
+
+// WithOutliers is an option to discard outliers among the y values
+// of each group at each x before plotting, using the specified rule
+// (e.g. "iqr").
+type WithOutliers string
+
+func (w WithOutliers) apply(p *plotOptions) {
+	p.outliers = string(w)
+}
+
+// WithShowOutliers is an option to still display discarded outliers
+// in scatter plots, in a muted style.
+type WithShowOutliers bool
+
+func (w WithShowOutliers) apply(p *plotOptions) {
+	p.showOutliers = bool(w)
+}
+
+// WithSummaryWriter is an option to specify where to write textual
+// summaries of the plotted data, such as the results of significance
+// tests or the number of discarded outliers.
+type WithSummaryWriter struct {
+	io.Writer
+}
+
+func (w WithSummaryWriter) apply(p *plotOptions) {
+	p.summary = w.Writer
+}
@@ -0,0 +1,133 @@
+package plot
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"text/tabwriter"
+)
+
+// The available rules for discarding outliers.
+const (
+	IQROutliers = "iqr"
+	MADOutliers = "mad"
+)
+
+const (
+	// iqrFactor is the number of interquartile ranges beyond the
+	// quartiles past which a value is an outlier.
+	iqrFactor = 1.5
+
+	// madThreshold is the number of scaled median absolute deviations
+	// from the median past which a value is an outlier.
+	madThreshold = 3
+
+	// madScale scales the median absolute deviation to be consistent
+	// with the standard deviation of normally distributed values.
+	madScale = 1.4826
+)
+
+// removeOutliers discards the outliers among the y values of each group
+// at each x according to the specified rule, returning the remaining
+// results along with the discarded results of any groups with outliers.
+func removeOutliers(splitGrouped map[string][]splitRes, rule string) (map[string][]splitRes, map[string][]splitRes, error) {
+	if rule != IQROutliers && rule != MADOutliers {
+		return nil, nil, fmt.Errorf("unknown outlier rule: %s", rule)
+	}
+
+	var (
+		kept    = make(map[string][]splitRes, len(splitGrouped))
+		dropped = map[string][]splitRes{}
+	)
+	for groupName, splitResults := range splitGrouped {
+		ys := make([]float64, len(splitResults))
+		valsByX := map[interface{}][]float64{}
+		for i, res := range splitResults {
+			yF, err := getFloat(res.y)
+			if err != nil {
+				return nil, nil, fmt.Errorf("cannot remove outliers from y data: %w", err)
+			}
+			ys[i] = yF
+			valsByX[res.x] = append(valsByX[res.x], yF)
+		}
+
+		boundsByX := make(map[interface{}][2]float64, len(valsByX))
+		for x, vals := range valsByX {
+			boundsByX[x] = outlierBounds(vals, rule)
+		}
+
+		keptResults := make([]splitRes, 0, len(splitResults))
+		for i, res := range splitResults {
+			if bounds := boundsByX[res.x]; ys[i] < bounds[0] || ys[i] > bounds[1] {
+				dropped[groupName] = append(dropped[groupName], res)
+				continue
+			}
+			keptResults = append(keptResults, res)
+		}
+		kept[groupName] = keptResults
+	}
+	return kept, dropped, nil
+}
+
+// outlierBounds returns the lowest and highest of the values which are
+// not outliers according to the rule. If the values have no spread
+// (e.g. when most runs are identical) no values are outliers, since
+// any other value would be infinitely far from the rest.
+func outlierBounds(vals []float64, rule string) [2]float64 {
+	sorted := make([]float64, len(vals))
+	copy(sorted, vals)
+	sort.Float64s(sorted)
+
+	noOutliers := [2]float64{math.Inf(-1), math.Inf(1)}
+	if rule == IQROutliers {
+		q1, q3 := quantile(sorted, 0.25), quantile(sorted, 0.75)
+		iqr := q3 - q1
+		if iqr == 0 {
+			return noOutliers
+		}
+		return [2]float64{q1 - iqrFactor*iqr, q3 + iqrFactor*iqr}
+	}
+
+	med := quantile(sorted, 0.5)
+	deviations := make([]float64, len(sorted))
+	for i, val := range sorted {
+		deviations[i] = math.Abs(val - med)
+	}
+	sort.Float64s(deviations)
+	spread := madThreshold * madScale * quantile(deviations, 0.5)
+	if spread == 0 {
+		return noOutliers
+	}
+	return [2]float64{med - spread, med + spread}
+}
+
+// writeOutlierSummary writes the number of results discarded as
+// outliers from each group, given the kept and dropped results.
+func writeOutlierSummary(w io.Writer, title, rule string, kept, dropped map[string][]splitRes) error {
+	// use sorted keys for consistent iteration order
+	groupNames := make([]string, len(kept))
+	j := 0
+	for k := range kept {
+		groupNames[j] = k
+		j++
+	}
+	sort.Strings(groupNames)
+
+	var numDropped, total int
+	for _, groupName := range groupNames {
+		numDropped += len(dropped[groupName])
+		total += len(kept[groupName]) + len(dropped[groupName])
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "%s: dropped %d of %d points as outliers (%s)\n", title, numDropped, total, rule)
+	for _, groupName := range groupNames {
+		n := len(dropped[groupName])
+		if n == 0 || groupName == "" {
+			continue
+		}
+		fmt.Fprintf(tw, "  %s\t%d of %d\n", groupName, n, len(kept[groupName])+n)
+	}
+	return tw.Flush()
+}
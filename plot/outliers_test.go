@@ -0,0 +1,122 @@
+package plot
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+var removeOutliersTests = map[string]struct {
+	splitGrouped    map[string][]splitRes
+	rule            string
+	expectedKept    map[string][]splitRes
+	expectedDropped map[string][]splitRes
+	expectErr       bool
+}{
+	"iqr": {
+		splitGrouped: map[string][]splitRes{
+			"impl=a": {{x: 1, y: 10.0}, {x: 1, y: 11.0}, {x: 1, y: 12.0}, {x: 1, y: 100.0}, {x: 2, y: 100.0}},
+			"impl=b": {{x: 1, y: 1.0}, {x: 1, y: 2.0}},
+		},
+		rule: IQROutliers,
+		expectedKept: map[string][]splitRes{
+			"impl=a": {{x: 1, y: 10.0}, {x: 1, y: 11.0}, {x: 1, y: 12.0}, {x: 2, y: 100.0}},
+			"impl=b": {{x: 1, y: 1.0}, {x: 1, y: 2.0}},
+		},
+		expectedDropped: map[string][]splitRes{
+			"impl=a": {{x: 1, y: 100.0}},
+		},
+	},
+	"mad": {
+		splitGrouped: map[string][]splitRes{
+			"": {{x: "a", y: 10.0}, {x: "a", y: 11.0}, {x: "a", y: 9.0}, {x: "a", y: 3.0}, {x: "a", y: 10.5}},
+		},
+		rule: MADOutliers,
+		expectedKept: map[string][]splitRes{
+			"": {{x: "a", y: 10.0}, {x: "a", y: 11.0}, {x: "a", y: 9.0}, {x: "a", y: 10.5}},
+		},
+		expectedDropped: map[string][]splitRes{
+			"": {{x: "a", y: 3.0}},
+		},
+	},
+	"mad,no_spread": {
+		splitGrouped: map[string][]splitRes{
+			"": {{x: 1, y: 5.0}, {x: 1, y: 5.0}, {x: 1, y: 5.0}, {x: 1, y: 4.0}, {x: 1, y: 6.0}},
+		},
+		rule: MADOutliers,
+		expectedKept: map[string][]splitRes{
+			"": {{x: 1, y: 5.0}, {x: 1, y: 5.0}, {x: 1, y: 5.0}, {x: 1, y: 4.0}, {x: 1, y: 6.0}},
+		},
+		expectedDropped: map[string][]splitRes{},
+	},
+	"iqr,no_spread": {
+		splitGrouped: map[string][]splitRes{
+			"": {{x: 1, y: 5.0}, {x: 1, y: 5.0}, {x: 1, y: 5.0}, {x: 1, y: 4.0}, {x: 1, y: 6.0}},
+		},
+		rule: IQROutliers,
+		expectedKept: map[string][]splitRes{
+			"": {{x: 1, y: 5.0}, {x: 1, y: 5.0}, {x: 1, y: 5.0}, {x: 1, y: 4.0}, {x: 1, y: 6.0}},
+		},
+		expectedDropped: map[string][]splitRes{},
+	},
+	"unknown_rule": {
+		splitGrouped: map[string][]splitRes{
+			"": {{x: 1, y: 5.0}},
+		},
+		rule:      "zscore",
+		expectErr: true,
+	},
+	"non_numeric_y": {
+		splitGrouped: map[string][]splitRes{
+			"": {{x: 1, y: "5"}},
+		},
+		rule:      IQROutliers,
+		expectErr: true,
+	},
+}
+
+func TestRemoveOutliers(t *testing.T) {
+	for testName, testCase := range removeOutliersTests {
+		t.Run(testName, func(t *testing.T) {
+			kept, dropped, err := removeOutliers(testCase.splitGrouped, testCase.rule)
+			if err != nil {
+				if !testCase.expectErr {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			if testCase.expectErr {
+				t.Fatalf("unexpectedly no error")
+			}
+
+			if !reflect.DeepEqual(kept, testCase.expectedKept) {
+				t.Errorf("unexpected kept results\nexpected:\n%v\nactual:\n%v", testCase.expectedKept, kept)
+			}
+			if !reflect.DeepEqual(dropped, testCase.expectedDropped) {
+				t.Errorf("unexpected dropped results\nexpected:\n%v\nactual:\n%v", testCase.expectedDropped, dropped)
+			}
+		})
+	}
+}
+
+func TestWriteOutlierSummary(t *testing.T) {
+	var (
+		kept = map[string][]splitRes{
+			"impl=a": {{x: 1, y: 10.0}, {x: 1, y: 11.0}, {x: 1, y: 12.0}},
+			"impl=b": {{x: 1, y: 1.0}, {x: 1, y: 2.0}},
+		}
+		dropped = map[string][]splitRes{
+			"impl=a": {{x: 1, y: 100.0}},
+		}
+		buf bytes.Buffer
+	)
+	if err := writeOutlierSummary(&buf, "BenchmarkMath", IQROutliers, kept, dropped); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := "BenchmarkMath: dropped 1 of 6 points as outliers (iqr)\n" +
+		"  impl=a  1 of 4\n"
+	if buf.String() != expected {
+		t.Errorf("unexpected summary\nexpected:\n%s\nactual:\n%s", expected, buf.String())
+	}
+}
@@ -0,0 +1,33 @@
+package plotter
+
+// MergeCategories combines the categories of each group into a
+// single list. Each group is expected to list its categories in
+// the same relative order, which is preserved in the result.
+func MergeCategories(groups [][]string) []string {
+	var (
+		merged = []string{}
+		seen   = map[string]bool{}
+	)
+	for _, categories := range groups {
+		// insert unseen categories directly after the preceding
+		// category of the same group
+		pos := 0
+		for _, category := range categories {
+			if seen[category] {
+				for k, existing := range merged {
+					if existing == category {
+						pos = k + 1
+						break
+					}
+				}
+				continue
+			}
+			seen[category] = true
+			merged = append(merged, "")
+			copy(merged[pos+1:], merged[pos:])
+			merged[pos] = category
+			pos++
+		}
+	}
+	return merged
+}
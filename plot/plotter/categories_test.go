@@ -0,0 +1,37 @@
+package plotter
+
+import (
+	"reflect"
+	"testing"
+)
+
+var mergeCategoriesTests = map[string]struct {
+	groups             [][]string
+	expectedCategories []string
+}{
+	"no_groups": {
+		expectedCategories: []string{},
+	},
+	"same_categories": {
+		groups:             [][]string{{"a", "b"}, {"a", "b"}},
+		expectedCategories: []string{"a", "b"},
+	},
+	"disjoint_categories": {
+		groups:             [][]string{{"a", "b"}, {"c"}},
+		expectedCategories: []string{"c", "a", "b"},
+	},
+	"interleaved_categories": {
+		groups:             [][]string{{"small", "large"}, {"small", "medium", "large"}},
+		expectedCategories: []string{"small", "medium", "large"},
+	},
+}
+
+func TestMergeCategories(t *testing.T) {
+	for testName, testCase := range mergeCategoriesTests {
+		t.Run(testName, func(t *testing.T) {
+			if categories := MergeCategories(testCase.groups); !reflect.DeepEqual(categories, testCase.expectedCategories) {
+				t.Errorf("unexpected categories (expected=%q, actual=%q)", testCase.expectedCategories, categories)
+			}
+		})
+	}
+}
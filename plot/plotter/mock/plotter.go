@@ -4,8 +4,15 @@ import "github.com/ShawnROGrady/benchplot/plot/plotter"
 
 // Plotter is a mock implementation of Plotter
 type Plotter struct {
-	PlotScatterFn func(data map[string]plotter.NumericData, title string, xLabel string, yLabel string, includeLegend bool) error
-	PlotLineFn    func(data map[string]plotter.NumericData, title string, xLabel string, yLabel string, includeLegend bool) error
+	PlotScatterFn      func(data map[string]plotter.NumericData, title string, xLabel string, yLabel string, includeLegend bool) error
+	PlotLineFn         func(data map[string]plotter.NumericData, title string, xLabel string, yLabel string, includeLegend bool) error
+	PlotBarFn          func(data map[string]plotter.CategoricalData, title string, xLabel string, yLabel string, includeLegend bool) error
+	PlotErrorBarsFn    func(data map[string]plotter.ErrorData, title string, xLabel string, yLabel string, includeLegend bool) error
+	PlotBoxFn          func(data map[string]plotter.DistributionData, title string, xLabel string, yLabel string, includeLegend bool) error
+	PlotSignificanceFn func(data map[string]plotter.SignificanceData, alpha float64, title string, xLabel string, yLabel string) error
+	PlotOutliersFn     func(data map[string]plotter.NumericData, title string, xLabel string, yLabel string) error
+	SetScalesFn        func(xScale plotter.Scale, yScale plotter.Scale) error
+	SetSubtitleFn      func(subtitle string) error
 }
 
 // PlotScatter returns _m.PlotScatterFn
@@ -17,3 +24,38 @@ func (_m *Plotter) PlotScatter(data map[string]plotter.NumericData, title string
 func (_m *Plotter) PlotLine(data map[string]plotter.NumericData, title string, xLabel string, yLabel string, includeLegend bool) error {
 	return _m.PlotLineFn(data, title, xLabel, yLabel, includeLegend)
 }
+
+// PlotBar returns _m.PlotBarFn
+func (_m *Plotter) PlotBar(data map[string]plotter.CategoricalData, title string, xLabel string, yLabel string, includeLegend bool) error {
+	return _m.PlotBarFn(data, title, xLabel, yLabel, includeLegend)
+}
+
+// PlotErrorBars returns _m.PlotErrorBarsFn
+func (_m *Plotter) PlotErrorBars(data map[string]plotter.ErrorData, title string, xLabel string, yLabel string, includeLegend bool) error {
+	return _m.PlotErrorBarsFn(data, title, xLabel, yLabel, includeLegend)
+}
+
+// PlotBox returns _m.PlotBoxFn
+func (_m *Plotter) PlotBox(data map[string]plotter.DistributionData, title string, xLabel string, yLabel string, includeLegend bool) error {
+	return _m.PlotBoxFn(data, title, xLabel, yLabel, includeLegend)
+}
+
+// PlotSignificance returns _m.PlotSignificanceFn
+func (_m *Plotter) PlotSignificance(data map[string]plotter.SignificanceData, alpha float64, title string, xLabel string, yLabel string) error {
+	return _m.PlotSignificanceFn(data, alpha, title, xLabel, yLabel)
+}
+
+// PlotOutliers returns _m.PlotOutliersFn
+func (_m *Plotter) PlotOutliers(data map[string]plotter.NumericData, title string, xLabel string, yLabel string) error {
+	return _m.PlotOutliersFn(data, title, xLabel, yLabel)
+}
+
+// SetScales returns _m.SetScalesFn
+func (_m *Plotter) SetScales(xScale plotter.Scale, yScale plotter.Scale) error {
+	return _m.SetScalesFn(xScale, yScale)
+}
+
+// SetSubtitle returns _m.SetSubtitleFn
+func (_m *Plotter) SetSubtitle(subtitle string) error {
+	return _m.SetSubtitleFn(subtitle)
+}
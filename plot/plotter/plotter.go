@@ -1,13 +1,102 @@
 package plotter
 
-// NumericData represents basic numeric data to plot.
+import "fmt"
+
+// Scale is the scale of an axis.
+type Scale string
+
+// The available axis scales.
+const (
+	LinearScale Scale = "linear"
+	Log2Scale   Scale = "log2"
+	Log10Scale  Scale = "log10"
+)
+
+// IsLog reports whether the scale is logarithmic.
+func (s Scale) IsLog() bool {
+	return s == Log2Scale || s == Log10Scale
+}
+
+// Check verifies the values can be displayed with the scale,
+// since log scales require all values to be positive.
+func (s Scale) Check(axisName string, vals ...float64) error {
+	if !s.IsLog() {
+		return nil
+	}
+	for _, val := range vals {
+		if val <= 0 {
+			return fmt.Errorf("cannot display %s value %v on %s scale", axisName, val, s)
+		}
+	}
+	return nil
+}
+
+// NumericData represents basic numeric data to plot. If the y values
+// were computed from the results at each x, Aggregation describes how
+// (e.g. "median").
 type NumericData struct {
+	X           []float64
+	Y           []float64
+	Aggregation string
+}
+
+// CategoricalData represents data to plot where each x value
+// is a distinct category rather than a number.
+type CategoricalData struct {
+	X []string
+	Y []float64
+}
+
+// ErrorData represents numeric data to plot along with the
+// error of each y value. The errors are relative to the
+// corresponding y value.
+type ErrorData struct {
+	X        []float64
+	Y        []float64
+	YErrLow  []float64
+	YErrHigh []float64
+}
+
+// DistributionData represents the distribution of y values at each
+// x value. If Labels is non-empty the x values are positions of
+// categories and Labels holds the name of each category. Stats holds
+// the summary of the y values at each x to draw.
+type DistributionData struct {
+	X      []float64
+	Labels []string
+	Y      [][]float64
+	Stats  []BoxStats
+}
+
+// BoxStats summarizes a distribution to draw as a box. The whiskers
+// extend to the most extreme values within 1.5 interquartile ranges
+// of the quartiles, and the values beyond them are outliers.
+type BoxStats struct {
+	Min, Max       float64
+	Q1, Median, Q3 float64
+	Low, High      float64 // the ends of the whiskers
+	Outliers       []float64
+}
+
+// SignificanceData represents the average y value at each x value
+// of a group along with the p-value of the difference from a baseline
+// group. A difference is significant if its p-value is less than the
+// significance level, and the p-value is NaN if it is unknown.
+type SignificanceData struct {
 	X []float64
 	Y []float64
+	P []float64
 }
 
 // Plotter defines the functionality needed to plot a benchmark.
 type Plotter interface {
 	PlotScatter(data map[string]NumericData, title, xLabel, yLabel string, includeLegend bool) error
 	PlotLine(data map[string]NumericData, title, xLabel, yLabel string, includeLegend bool) error
+	PlotBar(data map[string]CategoricalData, title, xLabel, yLabel string, includeLegend bool) error
+	PlotErrorBars(data map[string]ErrorData, title, xLabel, yLabel string, includeLegend bool) error
+	PlotBox(data map[string]DistributionData, title, xLabel, yLabel string, includeLegend bool) error
+	PlotSignificance(data map[string]SignificanceData, alpha float64, title, xLabel, yLabel string) error
+	PlotOutliers(data map[string]NumericData, title, xLabel, yLabel string) error
+	SetScales(xScale, yScale Scale) error
+	SetSubtitle(subtitle string) error
 }
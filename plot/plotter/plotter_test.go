@@ -0,0 +1,48 @@
+package plotter
+
+import "testing"
+
+var scaleCheckTests = map[string]struct {
+	scale     Scale
+	vals      []float64
+	expectErr bool
+}{
+	"linear,non_positive": {
+		scale: LinearScale,
+		vals:  []float64{-1, 0, 1},
+	},
+	"unset,non_positive": {
+		vals: []float64{0},
+	},
+	"log2,positive": {
+		scale: Log2Scale,
+		vals:  []float64{0.5, 1, 1024},
+	},
+	"log2,zero": {
+		scale:     Log2Scale,
+		vals:      []float64{1, 0},
+		expectErr: true,
+	},
+	"log10,negative": {
+		scale:     Log10Scale,
+		vals:      []float64{-10},
+		expectErr: true,
+	},
+}
+
+func TestScaleCheck(t *testing.T) {
+	for testName, testCase := range scaleCheckTests {
+		t.Run(testName, func(t *testing.T) {
+			err := testCase.scale.Check("y", testCase.vals...)
+			if err != nil {
+				if !testCase.expectErr {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			if testCase.expectErr {
+				t.Errorf("unexpectedly no error")
+			}
+		})
+	}
+}
@@ -1,13 +1,15 @@
 package plot
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/ShawnROGrady/benchparse"
 )
 
-// The benchmark output names. These are the available values
-// for the y-axis.
+// The benchmark output names. These, along with the units of
+// any custom metrics, are the available values for the y-axis.
 const (
 	RunsName        = "runs"
 	TimeName        = "time"
@@ -29,16 +31,45 @@ func benchOutputValByName(b benchparse.BenchOutputs, name string) (interface{},
 	case AllocMBytesRate:
 		return b.GetMBPerS()
 	default:
-		return nil, fmt.Errorf("no output found with name: '%s'", name)
+		if m, ok := b.(metricOutputs); ok {
+			if v, err := m.GetMetric(name); err == nil {
+				return v, nil
+			}
+		}
+		return nil, fmt.Errorf("no output found with name: '%s' (options = %q)", name, outputNames(b))
 	}
 }
 
+// metricOutputs are benchmark outputs which include custom metrics
+// reported via testing.B.ReportMetric, such as input.Outputs.
+type metricOutputs interface {
+	GetMetric(unit string) (float64, error)
+	MetricUnits() []string
+}
+
+// outputNames returns the names of the outputs available from b.
+func outputNames(b benchparse.BenchOutputs) []string {
+	names := []string{RunsName, TimeName, NumAllocsName, AllocBytesName, AllocMBytesRate}
+	if m, ok := b.(metricOutputs); ok {
+		names = append(names, m.MetricUnits()...)
+	}
+	return names
+}
+
+// ErrInputNotFound indicates that the results do not have
+// an input with the requested name.
+var ErrInputNotFound = errors.New("no input found")
+
 type splitRes struct {
 	x interface{}
 	y interface{}
 }
 
-func splitBenchRes(b benchparse.BenchRes, xName, yName string) (splitRes, error) {
+// splitBenchRes returns the x and y values of the result. If the y name
+// is not an output of the result it is evaluated as an expression,
+// unless it is the unit of a custom metric reported by other results,
+// in which case the metric was not measured for this result.
+func splitBenchRes(b benchparse.BenchRes, xName, yName string, yIsMetric bool) (splitRes, error) {
 	splitRes := splitRes{}
 	xFound := false
 	for _, varValue := range b.Inputs.VarValues {
@@ -50,10 +81,21 @@ func splitBenchRes(b benchparse.BenchRes, xName, yName string) (splitRes, error)
 	}
 
 	if !xFound {
-		return splitRes, fmt.Errorf("no input found with name: '%s'", xName)
+		return splitRes, fmt.Errorf("%w with name: '%s'", ErrInputNotFound, xName)
 	}
 
 	yVal, err := benchOutputValByName(b.Outputs, yName)
+	if err != nil && yIsMetric {
+		return splitRes, fmt.Errorf("error getting y value '%s': %w", yName, benchparse.ErrNotMeasured)
+	}
+	if err != nil && isExpr(yName) {
+		yF, err := evalExpr(b, yName)
+		if err != nil {
+			return splitRes, fmt.Errorf("error evaluating y expression '%s': %w", yName, err)
+		}
+		splitRes.y = yF
+		return splitRes, nil
+	}
 	if err != nil {
 		return splitRes, fmt.Errorf("error getting y value: %w", err)
 	}
@@ -62,12 +104,32 @@ func splitBenchRes(b benchparse.BenchRes, xName, yName string) (splitRes, error)
 	return splitRes, nil
 }
 
+// isMetric reports whether the name is the unit of a custom metric
+// reported by any of the results.
+func isMetric(g benchparse.GroupedResults, name string) bool {
+	for _, results := range g {
+		for _, res := range results {
+			m, ok := res.Outputs.(metricOutputs)
+			if !ok {
+				continue
+			}
+			if containsString(m.MetricUnits(), name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func splitGroupedResult(g benchparse.GroupedResults, xName, yName string) (map[string][]splitRes, error) {
-	splitGrouped := map[string][]splitRes{}
+	var (
+		splitGrouped = map[string][]splitRes{}
+		yIsMetric    = isMetric(g, yName)
+	)
 	for groupName, results := range g {
 		splitResults := make([]splitRes, len(results))
 		for i, res := range results {
-			split, err := splitBenchRes(res, xName, yName)
+			split, err := splitBenchRes(res, xName, yName, yIsMetric)
 			if err != nil {
 				return nil, err
 			}
@@ -77,3 +139,163 @@ func splitGroupedResult(g benchparse.GroupedResults, xName, yName string) (map[s
 	}
 	return splitGrouped, nil
 }
+
+// normalizeSplitGrouped divides each y value by the average y value of
+// the baseline group at the same x. The baseline is specified by the
+// components of its group name which differ from each group, for example
+// normalizing the group 'n=2,impl=fast' to the baseline 'impl=naive' uses
+// the group 'n=2,impl=naive'. Components without a variable name refer
+// to the labels of compared benchmarks.
+func normalizeSplitGrouped(splitGrouped map[string][]splitRes, xName, baseline string) (map[string][]splitRes, error) {
+	return normalizeSplitGroupedTo(splitGrouped, splitGrouped, xName, baseline)
+}
+
+// normalizeSplitGroupedTo normalizes each y value like normalizeSplitGrouped,
+// taking the baseline groups from baselineGrouped.
+func normalizeSplitGroupedTo(splitGrouped, baselineGrouped map[string][]splitRes, xName, baseline string) (map[string][]splitRes, error) {
+	var (
+		baselineComponents = strings.Split(baseline, ",")
+		baselineAvgs       = map[string]map[interface{}]float64{}
+		normalized         = map[string][]splitRes{}
+	)
+
+	baselineDims, err := baselineComponentDims(baselineGrouped, baselineComponents)
+	if err != nil {
+		return nil, err
+	}
+
+	for groupName, splitResults := range splitGrouped {
+		baselineName, err := baselineGroupName(groupName, baselineComponents, baselineDims)
+		if err != nil {
+			return nil, err
+		}
+
+		avgs, ok := baselineAvgs[baselineName]
+		if !ok {
+			baselineResults, ok := baselineGrouped[baselineName]
+			if !ok {
+				return nil, fmt.Errorf("no baseline group found with name: '%s'", baselineName)
+			}
+			avgs, err = avgByX(baselineResults)
+			if err != nil {
+				return nil, fmt.Errorf("error averaging baseline: %w", err)
+			}
+			baselineAvgs[baselineName] = avgs
+		}
+
+		normalizedResults := make([]splitRes, len(splitResults))
+		for i, res := range splitResults {
+			avg, ok := avgs[res.x]
+			if !ok {
+				return nil, fmt.Errorf("baseline group '%s' has no results with %s=%v", baselineName, xName, res.x)
+			}
+			if avg == 0 {
+				return nil, fmt.Errorf("baseline group '%s' has zero value at %s=%v", baselineName, xName, res.x)
+			}
+
+			yF, err := getFloat(res.y)
+			if err != nil {
+				return nil, fmt.Errorf("cannot normalize y data: %w", err)
+			}
+			normalizedResults[i] = splitRes{x: res.x, y: yF / avg}
+		}
+		normalized[groupName] = normalizedResults
+	}
+	return normalized, nil
+}
+
+// baselineComponentDims returns the dimension of each baseline component.
+// Since labels have no variable name, the dimension of a label is found
+// from the position of the label in the names of the groups.
+func baselineComponentDims(splitGrouped map[string][]splitRes, baselineComponents []string) ([]string, error) {
+	dims := componentDims(baselineComponents)
+	for i, baselineComponent := range baselineComponents {
+		if strings.Contains(baselineComponent, "=") {
+			continue
+		}
+
+		found := false
+		for groupName := range splitGrouped {
+			components := strings.Split(groupName, ",")
+			for j, groupDim := range componentDims(components) {
+				if components[j] == baselineComponent && !strings.Contains(components[j], "=") {
+					dims[i] = groupDim
+					found = true
+					break
+				}
+			}
+			if found {
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("no group found with label: '%s'", baselineComponent)
+		}
+	}
+	return dims, nil
+}
+
+// baselineGroupName returns the name of the baseline group for the group
+// by replacing each of its components with the baseline component of the
+// same dimension.
+func baselineGroupName(groupName string, baselineComponents, baselineDims []string) (string, error) {
+	if groupName == "" {
+		return "", errors.New("cannot normalize ungrouped results")
+	}
+
+	var (
+		components = strings.Split(groupName, ",")
+		dims       = componentDims(components)
+	)
+	for i, baselineComponent := range baselineComponents {
+		found := false
+		for j := range components {
+			if dims[j] == baselineDims[i] {
+				components[j] = baselineComponent
+				found = true
+				break
+			}
+		}
+		if !found {
+			return "", fmt.Errorf("group '%s' has no component corresponding to baseline '%s'", groupName, baselineComponent)
+		}
+	}
+	return strings.Join(components, ","), nil
+}
+
+// componentDims returns the dimension of each component of a group name.
+// This is the variable name for components of the form 'var_name=var_value',
+// and the position among the other labels for labels of compared benchmarks.
+func componentDims(components []string) []string {
+	var (
+		dims      = make([]string, len(components))
+		numLabels = 0
+	)
+	for i, component := range components {
+		if j := strings.Index(component, "="); j >= 0 {
+			dims[i] = component[:j]
+			continue
+		}
+		dims[i] = fmt.Sprintf("#%d", numLabels)
+		numLabels++
+	}
+	return dims
+}
+
+// avgByX returns the average y value corresponding to each x value.
+func avgByX(splitResults []splitRes) (map[interface{}]float64, error) {
+	vals := map[interface{}][]float64{}
+	for _, res := range splitResults {
+		yF, err := getFloat(res.y)
+		if err != nil {
+			return nil, err
+		}
+		vals[res.x] = append(vals[res.x], yF)
+	}
+
+	avgs := make(map[interface{}]float64, len(vals))
+	for x, yVals := range vals {
+		avgs[x] = mean(yVals)
+	}
+	return avgs, nil
+}
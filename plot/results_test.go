@@ -1,6 +1,7 @@
 package plot
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"testing"
@@ -130,6 +131,141 @@ func TestSplitGroupedResults(t *testing.T) {
 	}
 }
 
+var normalizeSplitGroupedTests = map[string]struct {
+	splitGrouped       map[string][]splitRes
+	baseline           string
+	expectedNormalized map[string][]splitRes
+	expectErr          bool
+}{
+	"single_component": {
+		splitGrouped: map[string][]splitRes{
+			"impl=naive": []splitRes{{x: 1, y: float64(100)}, {x: 1, y: float64(300)}, {x: 2, y: float64(400)}},
+			"impl=fast":  []splitRes{{x: 1, y: float64(50)}, {x: 2, y: float64(100)}},
+		},
+		baseline: "impl=naive",
+		expectedNormalized: map[string][]splitRes{
+			"impl=naive": []splitRes{{x: 1, y: 0.5}, {x: 1, y: 1.5}, {x: 2, y: float64(1)}},
+			"impl=fast":  []splitRes{{x: 1, y: 0.25}, {x: 2, y: 0.25}},
+		},
+	},
+	"multiple_components": {
+		splitGrouped: map[string][]splitRes{
+			"impl=naive,size=small": []splitRes{{x: 1, y: float64(100)}},
+			"impl=fast,size=small":  []splitRes{{x: 1, y: float64(50)}},
+			"impl=naive,size=large": []splitRes{{x: 1, y: float64(1000)}},
+			"impl=fast,size=large":  []splitRes{{x: 1, y: float64(100)}},
+		},
+		baseline: "impl=naive",
+		expectedNormalized: map[string][]splitRes{
+			"impl=naive,size=small": []splitRes{{x: 1, y: float64(1)}},
+			"impl=fast,size=small":  []splitRes{{x: 1, y: 0.5}},
+			"impl=naive,size=large": []splitRes{{x: 1, y: float64(1)}},
+			"impl=fast,size=large":  []splitRes{{x: 1, y: 0.1}},
+		},
+	},
+	"baseline_label": {
+		splitGrouped: map[string][]splitRes{
+			"old.txt,impl=fast": []splitRes{{x: "a", y: uint64(10)}},
+			"new.txt,impl=fast": []splitRes{{x: "a", y: uint64(5)}},
+		},
+		baseline: "old.txt",
+		expectedNormalized: map[string][]splitRes{
+			"old.txt,impl=fast": []splitRes{{x: "a", y: float64(1)}},
+			"new.txt,impl=fast": []splitRes{{x: "a", y: 0.5}},
+		},
+	},
+	"baseline_second_label": {
+		splitGrouped: map[string][]splitRes{
+			"old.txt,BenchmarkMapGet":     []splitRes{{x: 1, y: float64(100)}},
+			"old.txt,BenchmarkSyncMapGet": []splitRes{{x: 1, y: float64(200)}},
+			"new.txt,BenchmarkMapGet":     []splitRes{{x: 1, y: float64(50)}},
+			"new.txt,BenchmarkSyncMapGet": []splitRes{{x: 1, y: float64(150)}},
+		},
+		baseline: "BenchmarkMapGet",
+		expectedNormalized: map[string][]splitRes{
+			"old.txt,BenchmarkMapGet":     []splitRes{{x: 1, y: float64(1)}},
+			"old.txt,BenchmarkSyncMapGet": []splitRes{{x: 1, y: float64(2)}},
+			"new.txt,BenchmarkMapGet":     []splitRes{{x: 1, y: float64(1)}},
+			"new.txt,BenchmarkSyncMapGet": []splitRes{{x: 1, y: float64(3)}},
+		},
+	},
+	"baseline_unknown_label": {
+		splitGrouped: map[string][]splitRes{
+			"old.txt": []splitRes{{x: 1, y: float64(100)}},
+			"new.txt": []splitRes{{x: 1, y: float64(50)}},
+		},
+		baseline:  "other.txt",
+		expectErr: true,
+	},
+	"baseline_missing_x": {
+		splitGrouped: map[string][]splitRes{
+			"impl=naive": []splitRes{{x: 1, y: float64(100)}},
+			"impl=fast":  []splitRes{{x: 1, y: float64(50)}, {x: 2, y: float64(100)}},
+		},
+		baseline:  "impl=naive",
+		expectErr: true,
+	},
+	"baseline_zero": {
+		splitGrouped: map[string][]splitRes{
+			"impl=naive": []splitRes{{x: 1, y: float64(0)}},
+			"impl=fast":  []splitRes{{x: 1, y: float64(50)}},
+		},
+		baseline:  "impl=naive",
+		expectErr: true,
+	},
+	"baseline_not_found": {
+		splitGrouped: map[string][]splitRes{
+			"impl=naive": []splitRes{{x: 1, y: float64(100)}},
+			"impl=fast":  []splitRes{{x: 1, y: float64(50)}},
+		},
+		baseline:  "impl=other",
+		expectErr: true,
+	},
+	"baseline_unknown_var": {
+		splitGrouped: map[string][]splitRes{
+			"impl=naive": []splitRes{{x: 1, y: float64(100)}},
+		},
+		baseline:  "size=small",
+		expectErr: true,
+	},
+	"ungrouped": {
+		splitGrouped: map[string][]splitRes{
+			"": []splitRes{{x: 1, y: float64(100)}},
+		},
+		baseline:  "impl=naive",
+		expectErr: true,
+	},
+}
+
+func TestNormalizeSplitGrouped(t *testing.T) {
+	for testName, testCase := range normalizeSplitGroupedTests {
+		t.Run(testName, func(t *testing.T) {
+			normalized, err := normalizeSplitGrouped(testCase.splitGrouped, "x", testCase.baseline)
+			if err != nil {
+				if !testCase.expectErr {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+
+			if testCase.expectErr {
+				t.Errorf("unexpectedly no error")
+			}
+
+			if !reflect.DeepEqual(normalized, testCase.expectedNormalized) {
+				t.Errorf("unexpected normalized results\nexpected:\n%#v\nactual:\n%#v", testCase.expectedNormalized, normalized)
+			}
+		})
+	}
+}
+
+func TestSplitBenchResInputNotFound(t *testing.T) {
+	_, err := splitBenchRes(sampleBenchmark.Results[0], "invalid_var", TimeName, false)
+	if !errors.Is(err, ErrInputNotFound) {
+		t.Errorf("unexpected error (expected=%s, actual=%v)", ErrInputNotFound, err)
+	}
+}
+
 var splitBenchResErr error
 
 func BenchmarkSplitBenchRes(b *testing.B) {
@@ -153,7 +289,7 @@ func BenchmarkSplitBenchRes(b *testing.B) {
 
 			var err error
 			for i := 0; i < b.N; i++ {
-				_, err = splitBenchRes(res, "var0", TimeName)
+				_, err = splitBenchRes(res, "var0", TimeName, false)
 				if err != nil {
 					b.Fatalf("unexpected error: %s", err)
 				}
@@ -252,6 +388,56 @@ func TestGetOutputValByName(t *testing.T) {
 	}
 }
 
+var customMetricTests = map[string]struct {
+	output      benchparse.BenchOutputs
+	name        string
+	expectedV   interface{}
+	expectedErr bool
+}{
+	"custom_metric": {
+		output:    newTestMetricOutputs(100, map[string]float64{"hits/op": 2.5, "p99-ns": 1200}, withNsPerOp(500)),
+		name:      "hits/op",
+		expectedV: 2.5,
+	},
+	"standard_output_with_custom_metrics": {
+		output:    newTestMetricOutputs(100, map[string]float64{"hits/op": 2.5, "p99-ns": 1200}, withNsPerOp(500)),
+		name:      TimeName,
+		expectedV: float64(500),
+	},
+	"unknown_custom_metric": {
+		output:      newTestMetricOutputs(100, map[string]float64{"hits/op": 2.5, "p99-ns": 1200}, withNsPerOp(500)),
+		name:        "misses/op",
+		expectedErr: true,
+	},
+	"no_custom_metrics": {
+		output:      newTestOutputs(100, withNsPerOp(500)),
+		name:        "hits/op",
+		expectedErr: true,
+	},
+}
+
+func TestGetCustomMetricByName(t *testing.T) {
+	for testName, testCase := range customMetricTests {
+		t.Run(testName, func(t *testing.T) {
+			v, err := benchOutputValByName(testCase.output, testCase.name)
+			if err != nil {
+				if !testCase.expectedErr {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+
+			if testCase.expectedErr {
+				t.Errorf("unexpectedly no error")
+			}
+
+			if v != testCase.expectedV {
+				t.Errorf("unexpected value (expected=%v, actual=%v)", testCase.expectedV, v)
+			}
+		})
+	}
+}
+
 func testNsPerOp(t *testing.T, b benchparse.BenchOutputs, expectedV float64, expectedErr error) {
 	t.Helper()
 	ns, err := benchOutputValByName(b, TimeName)
@@ -0,0 +1,157 @@
+package plot
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ShawnROGrady/benchplot/plot/plotter"
+)
+
+// idealScalingName is the name of the line showing linear scaling.
+const idealScalingName = "ideal"
+
+// scalingPoint is the speedup and efficiency of a group when run with
+// a number of procs, relative to a single proc.
+type scalingPoint struct {
+	procs      float64
+	speedup    float64
+	efficiency float64
+}
+
+// isRate reports whether the named output is a rate (e.g. MB/s), for
+// which higher values are better, rather than a time per op.
+func isRate(yName string) bool {
+	return yName == AllocMBytesRate || strings.HasSuffix(yName, "/s")
+}
+
+// splitGroupedScaling computes the speedup T(1)/T(p) and efficiency
+// speedup/p of each group at each number of procs p, where T(p) is the
+// average y value with x = p. If the y values are rates the speedup is
+// instead R(p)/R(1), where R(p) is the average rate with x = p. Other
+// outputs (e.g. allocations) have no meaningful speedup.
+func splitGroupedScaling(splitGrouped map[string][]splitRes, xName, yName string) (map[string][]scalingPoint, error) {
+	rate := isRate(yName)
+	if !rate && yName != TimeName {
+		return nil, fmt.Errorf("cannot compute speedup of %s: only %s or a rate (e.g. %s or a custom '/s' metric) can be used", yName, TimeName, AllocMBytesRate)
+	}
+
+	scaling := make(map[string][]scalingPoint, len(splitGrouped))
+	for groupName, splitResults := range splitGrouped {
+		xData, yVals, err := valuesByX(splitResults)
+		if err != nil {
+			return nil, err
+		}
+
+		desc := "the results"
+		if groupName != "" {
+			desc = fmt.Sprintf("group '%s'", groupName)
+		}
+		baseline := -1
+		for i, x := range xData {
+			if x == 1 {
+				baseline = i
+			}
+		}
+		if baseline < 0 {
+			return nil, fmt.Errorf("cannot compute speedup of %s: no baseline results with %s=1", desc, xName)
+		}
+		t1 := mean(yVals[baseline])
+		if t1 == 0 {
+			return nil, fmt.Errorf("cannot compute speedup of %s: zero value with %s=1", desc, xName)
+		}
+
+		points := make([]scalingPoint, len(xData))
+		for i, x := range xData {
+			if x <= 0 {
+				return nil, fmt.Errorf("cannot compute efficiency of %s with %s=%v", desc, xName, x)
+			}
+			tp := mean(yVals[i])
+			if tp == 0 {
+				return nil, fmt.Errorf("cannot compute speedup of %s: zero value with %s=%v", desc, xName, x)
+			}
+			points[i] = scalingPoint{procs: x, speedup: t1 / tp}
+			if rate {
+				points[i].speedup = tp / t1
+			}
+			points[i].efficiency = points[i].speedup / x
+		}
+		scaling[groupName] = points
+	}
+	return scaling, nil
+}
+
+// scalingName returns the name of the group's speedup line, which
+// states its efficiency with the most procs.
+func scalingName(groupName, xName string, points []scalingPoint) string {
+	last := points[len(points)-1]
+	desc := fmt.Sprintf("efficiency=%.0f%% at %s=%v", 100*last.efficiency, xName, last.procs)
+	if groupName == "" {
+		return desc
+	}
+	return fmt.Sprintf("%s (%s)", groupName, desc)
+}
+
+// scalingPlotData returns the speedup of each group, keyed by a name
+// describing its efficiency, along with the ideal linear speedup. The
+// speedups are computed from the mean y values.
+func scalingPlotData(scaling map[string][]scalingPoint, xName string) map[string]plotter.NumericData {
+	var (
+		data  = make(map[string]plotter.NumericData, len(scaling)+1)
+		procs = map[float64]bool{}
+	)
+	for groupName, points := range scaling {
+		groupData := plotter.NumericData{
+			X:           make([]float64, len(points)),
+			Y:           make([]float64, len(points)),
+			Aggregation: MeanAgg,
+		}
+		for i, point := range points {
+			groupData.X[i] = point.procs
+			groupData.Y[i] = point.speedup
+			procs[point.procs] = true
+		}
+		data[scalingName(groupName, xName, points)] = groupData
+	}
+
+	ideal := plotter.NumericData{X: []float64{}}
+	for p := range procs {
+		ideal.X = append(ideal.X, p)
+	}
+	sort.Float64s(ideal.X)
+	ideal.Y = ideal.X
+	data[idealScalingName] = ideal
+	return data
+}
+
+// writeScalingSummary writes the speedup and efficiency of each group
+// at each number of procs.
+func writeScalingSummary(w io.Writer, title, xName string, scaling map[string][]scalingPoint) error {
+	// use sorted keys for consistent iteration order
+	groupNames := make([]string, len(scaling))
+	j := 0
+	for k := range scaling {
+		groupNames[j] = k
+		j++
+	}
+	sort.Strings(groupNames)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "%s (speedup, efficiency)\n", title)
+	for _, groupName := range groupNames {
+		if groupName != "" {
+			fmt.Fprintf(tw, "%s\n", groupName)
+		}
+		for _, point := range scaling[groupName] {
+			fmt.Fprintf(tw, "  %s=%v\t%.2fx\t%.0f%%\n", xName, point.procs, point.speedup, 100*point.efficiency)
+		}
+	}
+	return tw.Flush()
+}
+
+// errScalingCombined indicates that scaling plots were requested along
+// with plots, tests or normalization of the y values themselves.
+var errScalingCombined = errors.New("scaling plots cannot be combined with other plot types, significance tests or normalization")
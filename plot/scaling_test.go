@@ -0,0 +1,150 @@
+package plot
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/ShawnROGrady/benchplot/plot/plotter"
+)
+
+var splitGroupedScalingTests = map[string]struct {
+	splitGrouped    map[string][]splitRes
+	yName           string
+	expectedScaling map[string][]scalingPoint
+	expectErr       bool
+}{
+	"groups": {
+		yName: TimeName,
+		splitGrouped: map[string][]splitRes{
+			"n=10": {{x: 1, y: 100.0}, {x: 1, y: 100.0}, {x: 2, y: 50.0}, {x: 4, y: 40.0}, {x: 4, y: 40.0}},
+			"n=20": {{x: 4, y: 100.0}, {x: 1, y: 300.0}},
+		},
+		expectedScaling: map[string][]scalingPoint{
+			"n=10": {{procs: 1, speedup: 1, efficiency: 1}, {procs: 2, speedup: 2, efficiency: 1}, {procs: 4, speedup: 2.5, efficiency: 0.625}},
+			"n=20": {{procs: 1, speedup: 1, efficiency: 1}, {procs: 4, speedup: 3, efficiency: 0.75}},
+		},
+	},
+	"rate": {
+		splitGrouped: map[string][]splitRes{
+			"": {{x: 1, y: 100.0}, {x: 2, y: 150.0}, {x: 2, y: 170.0}},
+		},
+		yName: AllocMBytesRate,
+		expectedScaling: map[string][]scalingPoint{
+			"": {{procs: 1, speedup: 1, efficiency: 1}, {procs: 2, speedup: 1.6, efficiency: 0.8}},
+		},
+	},
+	"custom_rate": {
+		splitGrouped: map[string][]splitRes{
+			"": {{x: 1, y: 50.0}, {x: 4, y: 150.0}},
+		},
+		yName: "ops/s",
+		expectedScaling: map[string][]scalingPoint{
+			"": {{procs: 1, speedup: 1, efficiency: 1}, {procs: 4, speedup: 3, efficiency: 0.75}},
+		},
+	},
+	"not_time_or_rate": {
+		splitGrouped: map[string][]splitRes{
+			"": {{x: 1, y: 100.0}, {x: 2, y: 50.0}},
+		},
+		yName:     AllocBytesName,
+		expectErr: true,
+	},
+	"missing_baseline": {
+		yName: TimeName,
+		splitGrouped: map[string][]splitRes{
+			"n=10": {{x: 1, y: 100.0}, {x: 2, y: 50.0}},
+			"n=20": {{x: 2, y: 100.0}, {x: 4, y: 60.0}},
+		},
+		expectErr: true,
+	},
+	"zero_value": {
+		yName: TimeName,
+		splitGrouped: map[string][]splitRes{
+			"": {{x: 1, y: 100.0}, {x: 2, y: 0.0}},
+		},
+		expectErr: true,
+	},
+	"zero_baseline,rate": {
+		splitGrouped: map[string][]splitRes{
+			"": {{x: 1, y: 0.0}, {x: 2, y: 10.0}},
+		},
+		yName:     AllocMBytesRate,
+		expectErr: true,
+	},
+	"non_numeric_x": {
+		yName: TimeName,
+		splitGrouped: map[string][]splitRes{
+			"": {{x: "a", y: 100.0}},
+		},
+		expectErr: true,
+	},
+}
+
+func TestSplitGroupedScaling(t *testing.T) {
+	for testName, testCase := range splitGroupedScalingTests {
+		t.Run(testName, func(t *testing.T) {
+			scaling, err := splitGroupedScaling(testCase.splitGrouped, ProcsName, testCase.yName)
+			if err != nil {
+				if !testCase.expectErr {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			if testCase.expectErr {
+				t.Fatalf("unexpectedly no error")
+			}
+
+			if !reflect.DeepEqual(scaling, testCase.expectedScaling) {
+				t.Errorf("unexpected scaling\nexpected:\n%v\nactual:\n%v", testCase.expectedScaling, scaling)
+			}
+		})
+	}
+}
+
+func TestScalingPlotData(t *testing.T) {
+	scaling := map[string][]scalingPoint{
+		"":      {{procs: 1, speedup: 1, efficiency: 1}, {procs: 4, speedup: 3, efficiency: 0.75}},
+		"n=100": {{procs: 1, speedup: 1, efficiency: 1}, {procs: 2, speedup: 1.6, efficiency: 0.8}},
+	}
+
+	expected := map[string]plotter.NumericData{
+		"efficiency=75% at procs=4": {
+			X:           []float64{1, 4},
+			Y:           []float64{1, 3},
+			Aggregation: MeanAgg,
+		},
+		"n=100 (efficiency=80% at procs=2)": {
+			X:           []float64{1, 2},
+			Y:           []float64{1, 1.6},
+			Aggregation: MeanAgg,
+		},
+		"ideal": {
+			X: []float64{1, 2, 4},
+			Y: []float64{1, 2, 4},
+		},
+	}
+	if data := scalingPlotData(scaling, ProcsName); !reflect.DeepEqual(data, expected) {
+		t.Errorf("unexpected plot data\nexpected:\n%v\nactual:\n%v", expected, data)
+	}
+}
+
+func TestWriteScalingSummary(t *testing.T) {
+	var (
+		scaling = map[string][]scalingPoint{
+			"n=10": {{procs: 1, speedup: 1, efficiency: 1}, {procs: 4, speedup: 2.5, efficiency: 0.625}},
+		}
+		buf bytes.Buffer
+	)
+	if err := writeScalingSummary(&buf, "BenchmarkPar", ProcsName, scaling); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := "BenchmarkPar (speedup, efficiency)\n" +
+		"n=10\n" +
+		"  procs=1  1.00x  100%\n" +
+		"  procs=4  2.50x  62%\n"
+	if buf.String() != expected {
+		t.Errorf("unexpected summary\nexpected:\n%s\nactual:\n%s", expected, buf.String())
+	}
+}
@@ -0,0 +1,208 @@
+package plot
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ShawnROGrady/benchplot/plot/plotter"
+	"github.com/ShawnROGrady/benchplot/stats"
+)
+
+// significanceResult is the comparison of a group to its baseline
+// at a single x value.
+type significanceResult struct {
+	group        string
+	baseline     string
+	x            interface{}
+	mean         float64
+	baselineMean float64
+	test         stats.MannWhitneyUResult
+	err          error
+}
+
+// significant reports whether the difference from the baseline is
+// significant at the specified level.
+func (s significanceResult) significant(alpha float64) bool {
+	return s.err == nil && s.test.P < alpha
+}
+
+// delta returns the change in the mean relative to the baseline.
+func (s significanceResult) delta() float64 {
+	return (s.mean - s.baselineMean) / s.baselineMean
+}
+
+// compareSplitGrouped tests whether the y values of each group differ
+// from those of its baseline group at each x value, using the
+// Mann-Whitney U test. If no baseline is specified there must be
+// exactly two groups, and the first in sorted order is the baseline.
+func compareSplitGrouped(splitGrouped map[string][]splitRes, baseline string) ([]significanceResult, error) {
+	groupNames := make([]string, 0, len(splitGrouped))
+	for groupName := range splitGrouped {
+		groupNames = append(groupNames, groupName)
+	}
+	sort.Strings(groupNames)
+
+	baselineNames := make(map[string]string, len(groupNames))
+	switch {
+	case len(groupNames) < 2:
+		return nil, errors.New("significance testing requires at least two groups")
+	case baseline == "" && len(groupNames) != 2:
+		return nil, fmt.Errorf("a baseline is required to test the significance of %d groups", len(groupNames))
+	case baseline == "":
+		baselineNames[groupNames[1]] = groupNames[0]
+	default:
+		baselineComponents := strings.Split(baseline, ",")
+		baselineDims, err := baselineComponentDims(splitGrouped, baselineComponents)
+		if err != nil {
+			return nil, err
+		}
+		for _, groupName := range groupNames {
+			baselineName, err := baselineGroupName(groupName, baselineComponents, baselineDims)
+			if err != nil {
+				return nil, err
+			}
+			if baselineName == groupName {
+				continue
+			}
+			if _, ok := splitGrouped[baselineName]; !ok {
+				return nil, fmt.Errorf("no baseline group found with name: '%s'", baselineName)
+			}
+			baselineNames[groupName] = baselineName
+		}
+	}
+
+	results := []significanceResult{}
+	for _, groupName := range groupNames {
+		baselineName, ok := baselineNames[groupName]
+		if !ok {
+			continue
+		}
+
+		xs, ys, err := rawValuesByX(splitGrouped[groupName])
+		if err != nil {
+			return nil, err
+		}
+		_, baselineVals, err := rawValuesByX(splitGrouped[baselineName])
+		if err != nil {
+			return nil, err
+		}
+
+		for _, x := range xs {
+			baselineYs, ok := baselineVals[x]
+			if !ok {
+				continue
+			}
+			res := significanceResult{
+				group:        groupName,
+				baseline:     baselineName,
+				x:            x,
+				mean:         mean(ys[x]),
+				baselineMean: mean(baselineYs),
+			}
+			res.test, res.err = stats.MannWhitneyUTest(ys[x], baselineYs)
+			results = append(results, res)
+		}
+	}
+	return results, nil
+}
+
+// rawValuesByX returns the distinct x values of the results, sorted if
+// numeric and otherwise in order of first appearance, along with the
+// y values corresponding to each x.
+func rawValuesByX(splitResults []splitRes) ([]interface{}, map[interface{}][]float64, error) {
+	var (
+		xs = []interface{}{}
+		ys = map[interface{}][]float64{}
+	)
+	for _, res := range splitResults {
+		yF, err := getFloat(res.y)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot test significance of y data: %w", err)
+		}
+		if _, ok := ys[res.x]; !ok {
+			xs = append(xs, res.x)
+		}
+		ys[res.x] = append(ys[res.x], yF)
+	}
+
+	if allNumeric(xs) {
+		sort.SliceStable(xs, func(i, j int) bool {
+			xi, _ := getFloat(xs[i])
+			xj, _ := getFloat(xs[j])
+			return xi < xj
+		})
+	}
+	return xs, ys, nil
+}
+
+// allNumeric reports whether every value can be plotted as a number.
+func allNumeric(vals []interface{}) bool {
+	for _, v := range vals {
+		if _, err := getFloat(v); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// significanceData returns the mean y value of each compared group at
+// each x along with the p-value of its difference from the baseline.
+// Baseline groups are included without any values so every group is
+// present. The p-value is NaN if the test could not be performed.
+func significanceData(splitGrouped map[string][]splitRes, results []significanceResult) (map[string]plotter.SignificanceData, error) {
+	data := make(map[string]plotter.SignificanceData, len(splitGrouped))
+	for groupName := range splitGrouped {
+		data[groupName] = plotter.SignificanceData{X: []float64{}, Y: []float64{}, P: []float64{}}
+	}
+	for _, res := range results {
+		x, err := getFloat(res.x)
+		if err != nil {
+			return nil, fmt.Errorf("cannot mark significance of x data: %w", err)
+		}
+		p := res.test.P
+		if res.err != nil {
+			p = math.NaN()
+		}
+
+		groupData := data[res.group]
+		groupData.X = append(groupData.X, x)
+		groupData.Y = append(groupData.Y, res.mean)
+		groupData.P = append(groupData.P, p)
+		data[res.group] = groupData
+	}
+	return data, nil
+}
+
+// writeSignificanceSummary writes a table of the change from the
+// baseline at each x, along with whether the change is significant.
+func writeSignificanceSummary(w io.Writer, title, xName string, alpha float64, results []significanceResult) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "%s (Mann-Whitney U test, α=%v)\n", title, alpha)
+
+	prev := ""
+	for _, res := range results {
+		comparison := fmt.Sprintf("%s vs %s", res.group, res.baseline)
+		if comparison != prev {
+			fmt.Fprintf(tw, "%s\n", comparison)
+			prev = comparison
+		}
+
+		var (
+			delta = fmt.Sprintf("%+.2f%%", 100*res.delta())
+			mark  = "~"
+			pDesc = fmt.Sprintf("(p=%.3f n=%d+%d)", res.test.P, res.test.N1, res.test.N2)
+		)
+		if res.err != nil {
+			pDesc = fmt.Sprintf("(%s)", res.err)
+		} else if res.significant(alpha) {
+			mark = "*"
+		}
+		fmt.Fprintf(tw, "  %s=%v\t%s\t%s\t%s\n", xName, res.x, delta, pDesc, mark)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,117 @@
+package plot
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/ShawnROGrady/benchplot/stats"
+)
+
+var compareSplitGroupedTests = map[string]struct {
+	splitGrouped     map[string][]splitRes
+	baseline         string
+	expectedCompared [][2]string
+	expectedP        []float64
+	expectErr        bool
+}{
+	"two_groups,no_baseline": {
+		splitGrouped: map[string][]splitRes{
+			"impl=a": {{x: 1, y: 1.0}, {x: 1, y: 2.0}, {x: 1, y: 3.0}, {x: 2, y: 1.0}},
+			"impl=b": {{x: 1, y: 4.0}, {x: 1, y: 5.0}, {x: 1, y: 6.0}, {x: 2, y: 1.0}},
+		},
+		expectedCompared: [][2]string{{"impl=b", "impl=a"}, {"impl=b", "impl=a"}},
+		expectedP:        []float64{0.1, math.NaN()},
+	},
+	"three_groups,baseline": {
+		splitGrouped: map[string][]splitRes{
+			"old.txt,impl=a": {{x: 1, y: 1.0}, {x: 1, y: 2.0}},
+			"new.txt,impl=a": {{x: 1, y: 3.0}, {x: 1, y: 4.0}},
+			"new.txt,impl=b": {{x: 1, y: 1.0}, {x: 1, y: 2.0}, {x: 3, y: 1.0}},
+		},
+		baseline:         "impl=a",
+		expectedCompared: [][2]string{{"new.txt,impl=b", "new.txt,impl=a"}},
+		expectedP:        []float64{1.0 / 3},
+	},
+	"three_groups,no_baseline": {
+		splitGrouped: map[string][]splitRes{
+			"impl=a": {{x: 1, y: 1.0}},
+			"impl=b": {{x: 1, y: 1.0}},
+			"impl=c": {{x: 1, y: 1.0}},
+		},
+		expectErr: true,
+	},
+	"single_group": {
+		splitGrouped: map[string][]splitRes{
+			"impl=a": {{x: 1, y: 1.0}},
+		},
+		expectErr: true,
+	},
+	"missing_baseline": {
+		splitGrouped: map[string][]splitRes{
+			"impl=a": {{x: 1, y: 1.0}},
+			"impl=b": {{x: 1, y: 1.0}},
+		},
+		baseline:  "impl=c",
+		expectErr: true,
+	},
+}
+
+func TestCompareSplitGrouped(t *testing.T) {
+	for testName, testCase := range compareSplitGroupedTests {
+		t.Run(testName, func(t *testing.T) {
+			results, err := compareSplitGrouped(testCase.splitGrouped, testCase.baseline)
+			if err != nil {
+				if !testCase.expectErr {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			if testCase.expectErr {
+				t.Fatalf("unexpectedly no error")
+			}
+
+			if len(results) != len(testCase.expectedCompared) {
+				t.Fatalf("unexpected number of results (expected = %d, actual = %d)", len(testCase.expectedCompared), len(results))
+			}
+			for i, res := range results {
+				if compared := [2]string{res.group, res.baseline}; compared != testCase.expectedCompared[i] {
+					t.Errorf("unexpected comparison %d (expected = %q, actual = %q)", i, testCase.expectedCompared[i], compared)
+				}
+
+				expectedP := testCase.expectedP[i]
+				if math.IsNaN(expectedP) {
+					if res.err == nil {
+						t.Errorf("expected error for comparison %d, got p = %v", i, res.test.P)
+					}
+					continue
+				}
+				if res.err != nil {
+					t.Errorf("unexpected error for comparison %d: %s", i, res.err)
+				} else if math.Abs(res.test.P-expectedP) > 1e-12 {
+					t.Errorf("unexpected p-value for comparison %d (expected = %v, actual = %v)", i, expectedP, res.test.P)
+				}
+			}
+		})
+	}
+}
+
+func TestWriteSignificanceSummary(t *testing.T) {
+	results := []significanceResult{
+		{group: "impl=b", baseline: "impl=a", x: 1, mean: 5, baselineMean: 2, test: stats.MannWhitneyUResult{N1: 3, N2: 3, U: 9, P: 0.1}},
+		{group: "impl=b", baseline: "impl=a", x: 10, mean: 1, baselineMean: 1, err: stats.ErrSamplesEqual},
+	}
+
+	var buf bytes.Buffer
+	if err := writeSignificanceSummary(&buf, "BenchmarkMath", "n", 0.2, results); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := "BenchmarkMath (Mann-Whitney U test, α=0.2)\n" +
+		"impl=b vs impl=a\n" +
+		"  n=1   +150.00%  (p=0.100 n=3+3)          *\n" +
+		"  n=10  +0.00%    (all samples are equal)  ~\n"
+	if buf.String() != expected {
+		t.Errorf("unexpected summary\nexpected:\n%s\nactual:\n%s", expected, buf.String())
+	}
+}
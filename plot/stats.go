@@ -0,0 +1,161 @@
+package plot
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ShawnROGrady/benchplot/plot/plotter"
+)
+
+// The available aggregations of the y values at each x.
+const (
+	MeanAgg    = "mean"
+	MedianAgg  = "median"
+	GeoMeanAgg = "geomean"
+	MinAgg     = "min"
+	MaxAgg     = "max"
+)
+
+// errAggAvgLine indicates that an aggregation was requested along with
+// avg_line plots, which always show the mean.
+var errAggAvgLine = fmt.Errorf("an aggregation cannot be used with %s plots, which always show the mean (use %s instead)", AvgLineType, AggLineType)
+
+// percentileAggPrefix is the prefix of percentile aggregations,
+// for example "p90".
+const percentileAggPrefix = "p"
+
+func mean(vals []float64) float64 {
+	var tot float64 = 0
+	for _, val := range vals {
+		tot += val
+	}
+	return tot / float64(len(vals))
+}
+
+// stdDev returns the sample standard deviation of the values.
+func stdDev(vals []float64) float64 {
+	if len(vals) < 2 {
+		return 0
+	}
+	var (
+		m      = mean(vals)
+		sumSqr float64
+	)
+	for _, val := range vals {
+		sumSqr += (val - m) * (val - m)
+	}
+	return math.Sqrt(sumSqr / float64(len(vals)-1))
+}
+
+// yErr returns the error below and above the mean of the values
+// for the specified kind of error.
+func yErr(vals []float64, errKind string) (float64, float64, error) {
+	switch errKind {
+	case StdDevErr:
+		s := stdDev(vals)
+		return s, s, nil
+	case StdErrErr:
+		s := stdDev(vals) / math.Sqrt(float64(len(vals)))
+		return s, s, nil
+	case MinMaxErr:
+		var (
+			m        = mean(vals)
+			min, max = vals[0], vals[0]
+		)
+		for _, val := range vals[1:] {
+			min = math.Min(min, val)
+			max = math.Max(max, val)
+		}
+		return m - min, max - m, nil
+	default:
+		return 0, 0, fmt.Errorf("unknown error kind: %s", errKind)
+	}
+}
+
+// aggregate returns the aggregate of the values, which is one of the
+// available aggregations or a percentile (e.g. "p90").
+func aggregate(vals []float64, agg string) (float64, error) {
+	if len(vals) == 0 {
+		return 0, fmt.Errorf("cannot compute %s of no values", agg)
+	}
+
+	sorted := make([]float64, len(vals))
+	copy(sorted, vals)
+	sort.Float64s(sorted)
+
+	switch agg {
+	case MeanAgg:
+		return mean(vals), nil
+	case MedianAgg:
+		return quantile(sorted, 0.5), nil
+	case GeoMeanAgg:
+		if sorted[0] <= 0 {
+			return 0, fmt.Errorf("cannot compute %s of non-positive value %v", agg, sorted[0])
+		}
+		var sumLog float64
+		for _, val := range vals {
+			sumLog += math.Log(val)
+		}
+		return math.Exp(sumLog / float64(len(vals))), nil
+	case MinAgg:
+		return sorted[0], nil
+	case MaxAgg:
+		return sorted[len(sorted)-1], nil
+	}
+
+	if strings.HasPrefix(agg, percentileAggPrefix) {
+		pct, err := strconv.ParseFloat(strings.TrimPrefix(agg, percentileAggPrefix), 64)
+		if err == nil && pct >= 0 && pct <= 100 {
+			return quantile(sorted, pct/100), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown aggregation: %s", agg)
+}
+
+// quantile returns the q-th quantile of the sorted values, linearly
+// interpolating between the closest ranks.
+func quantile(sorted []float64, q float64) float64 {
+	pos := q * float64(len(sorted)-1)
+	lower := int(pos)
+	if lower+1 >= len(sorted) {
+		return sorted[len(sorted)-1]
+	}
+	frac := pos - float64(lower)
+	return sorted[lower] + frac*(sorted[lower+1]-sorted[lower])
+}
+
+// boxStats summarizes the values to draw as a box, so that every
+// plotter draws the same quartiles, whiskers, and outliers.
+func boxStats(vals []float64) plotter.BoxStats {
+	sorted := make([]float64, len(vals))
+	copy(sorted, vals)
+	sort.Float64s(sorted)
+
+	var (
+		stats = plotter.BoxStats{
+			Min:      sorted[0],
+			Max:      sorted[len(sorted)-1],
+			Q1:       quantile(sorted, 0.25),
+			Median:   quantile(sorted, 0.5),
+			Q3:       quantile(sorted, 0.75),
+			Low:      math.Inf(1),
+			High:     math.Inf(-1),
+			Outliers: []float64{},
+		}
+		iqr  = stats.Q3 - stats.Q1
+		low  = stats.Q1 - iqrFactor*iqr
+		high = stats.Q3 + iqrFactor*iqr
+	)
+	for _, val := range sorted {
+		if val < low || val > high {
+			stats.Outliers = append(stats.Outliers, val)
+			continue
+		}
+		stats.Low = math.Min(stats.Low, val)
+		stats.High = math.Max(stats.High, val)
+	}
+	return stats
+}
@@ -0,0 +1,101 @@
+package plot
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/ShawnROGrady/benchplot/plot/plotter"
+)
+
+var aggregateTests = map[string]struct {
+	vals      []float64
+	agg       string
+	expected  float64
+	expectErr bool
+}{
+	"mean":                 {vals: []float64{4, 1, 2, 1}, agg: MeanAgg, expected: 2},
+	"median,odd":           {vals: []float64{5, 1, 3}, agg: MedianAgg, expected: 3},
+	"median,even":          {vals: []float64{4, 1, 2, 1}, agg: MedianAgg, expected: 1.5},
+	"geomean":              {vals: []float64{1, 4, 16}, agg: GeoMeanAgg, expected: 4},
+	"geomean,non_positive": {vals: []float64{1, 0}, agg: GeoMeanAgg, expectErr: true},
+	"min":                  {vals: []float64{4, 1, 2}, agg: MinAgg, expected: 1},
+	"max":                  {vals: []float64{4, 1, 2}, agg: MaxAgg, expected: 4},
+	"p90":                  {vals: []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}, agg: "p90", expected: 10},
+	"p0":                   {vals: []float64{3, 2}, agg: "p0", expected: 2},
+	"p99.9":                {vals: []float64{0, 1000}, agg: "p99.9", expected: 999},
+	"p101":                 {vals: []float64{1}, agg: "p101", expectErr: true},
+	"unknown":              {vals: []float64{1}, agg: "mode", expectErr: true},
+	"no_values":            {vals: []float64{}, agg: MeanAgg, expectErr: true},
+}
+
+func TestAggregate(t *testing.T) {
+	for testName, testCase := range aggregateTests {
+		t.Run(testName, func(t *testing.T) {
+			res, err := aggregate(testCase.vals, testCase.agg)
+			if err != nil {
+				if !testCase.expectErr {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			if testCase.expectErr {
+				t.Fatalf("unexpectedly no error")
+			}
+
+			if math.Abs(res-testCase.expected) > 1e-9 {
+				t.Errorf("unexpected %s (expected = %v, actual = %v)", testCase.agg, testCase.expected, res)
+			}
+		})
+	}
+}
+
+func TestQuantile(t *testing.T) {
+	var tests = map[string]struct {
+		sorted   []float64
+		q        float64
+		expected float64
+	}{
+		"single":       {sorted: []float64{3}, q: 0.25, expected: 3},
+		"median,odd":   {sorted: []float64{1, 2, 10}, q: 0.5, expected: 2},
+		"median,even":  {sorted: []float64{1, 2, 4, 10}, q: 0.5, expected: 3},
+		"interpolated": {sorted: []float64{1, 2, 3, 4, 5}, q: 0.75, expected: 4},
+		"max":          {sorted: []float64{1, 2}, q: 1, expected: 2},
+	}
+
+	for testName, testCase := range tests {
+		t.Run(testName, func(t *testing.T) {
+			if actual := quantile(testCase.sorted, testCase.q); actual != testCase.expected {
+				t.Errorf("unexpected quantile (expected = %v, actual = %v)", testCase.expected, actual)
+			}
+		})
+	}
+}
+
+var boxStatsTests = map[string]struct {
+	vals          []float64
+	expectedStats plotter.BoxStats
+}{
+	"single": {
+		vals:          []float64{3},
+		expectedStats: plotter.BoxStats{Min: 3, Max: 3, Q1: 3, Median: 3, Q3: 3, Low: 3, High: 3, Outliers: []float64{}},
+	},
+	"no_outliers": {
+		vals:          []float64{4, 1, 3, 2, 5},
+		expectedStats: plotter.BoxStats{Min: 1, Max: 5, Q1: 2, Median: 3, Q3: 4, Low: 1, High: 5, Outliers: []float64{}},
+	},
+	"outliers": {
+		vals:          []float64{20, 2, 3, 4, 5, -10},
+		expectedStats: plotter.BoxStats{Min: -10, Max: 20, Q1: 2.25, Median: 3.5, Q3: 4.75, Low: 2, High: 5, Outliers: []float64{-10, 20}},
+	},
+}
+
+func TestBoxStats(t *testing.T) {
+	for testName, testCase := range boxStatsTests {
+		t.Run(testName, func(t *testing.T) {
+			if stats := boxStats(testCase.vals); !reflect.DeepEqual(stats, testCase.expectedStats) {
+				t.Errorf("unexpected stats (expected = %+v, actual = %+v)", testCase.expectedStats, stats)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package plot
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// errUnitNormalized indicates that a unit was requested for normalized
+// y values, which are ratios to the baseline and so have no unit.
+var errUnitNormalized = errors.New("a y unit cannot be used when normalizing results, since normalized values are relative to the baseline")
+
+// unitScale is a unit an output can be displayed in, along
+// with the factor to divide the base unit by to convert to it.
+type unitScale struct {
+	name   string
+	factor float64
+}
+
+// The units of each output, in ascending order of size.
+var (
+	timeUnits = []unitScale{
+		{name: "ns/op", factor: 1},
+		{name: "µs/op", factor: 1e3},
+		{name: "ms/op", factor: 1e6},
+		{name: "s/op", factor: 1e9},
+	}
+	allocBytesUnits = []unitScale{
+		{name: "B/op", factor: 1},
+		{name: "KiB/op", factor: 1 << 10},
+		{name: "MiB/op", factor: 1 << 20},
+		{name: "GiB/op", factor: 1 << 30},
+	}
+	numAllocsUnits = []unitScale{
+		{name: "allocs/op", factor: 1},
+	}
+	allocMBytesRateUnits = []unitScale{
+		{name: "MB/s", factor: 1},
+		{name: "GB/s", factor: 1e3},
+	}
+)
+
+// outputUnits returns the units the named output can be displayed
+// in, or nil if the output has no units.
+func outputUnits(name string) []unitScale {
+	switch name {
+	case TimeName:
+		return timeUnits
+	case AllocBytesName:
+		return allocBytesUnits
+	case NumAllocsName:
+		return numAllocsUnits
+	case AllocMBytesRate:
+		return allocMBytesRateUnits
+	default:
+		return nil
+	}
+}
+
+// selectUnit returns the unit to display the y values in. If unitName
+// is empty the largest unit in which the maximum y value is at least
+// 1 is selected.
+func selectUnit(splitGrouped map[string][]splitRes, units []unitScale, unitName string) (unitScale, error) {
+	if unitName != "" {
+		names := make([]string, len(units))
+		for i, u := range units {
+			if u.name == unitName {
+				return u, nil
+			}
+			names[i] = u.name
+		}
+		return unitScale{}, fmt.Errorf("unknown unit: '%s' (options = %q)", unitName, names)
+	}
+
+	var maxY float64
+	for _, splitResults := range splitGrouped {
+		for _, res := range splitResults {
+			yF, err := getFloat(res.y)
+			if err != nil {
+				return unitScale{}, err
+			}
+			maxY = math.Max(maxY, math.Abs(yF))
+		}
+	}
+
+	selected := units[0]
+	for _, u := range units[1:] {
+		if maxY < u.factor {
+			break
+		}
+		selected = u
+	}
+	return selected, nil
+}
+
+// scaleSplitGrouped converts each y value from the base unit to u.
+func scaleSplitGrouped(splitGrouped map[string][]splitRes, u unitScale) (map[string][]splitRes, error) {
+	scaled := make(map[string][]splitRes, len(splitGrouped))
+	for groupName, splitResults := range splitGrouped {
+		scaledResults := make([]splitRes, len(splitResults))
+		for i, res := range splitResults {
+			yF, err := getFloat(res.y)
+			if err != nil {
+				return nil, fmt.Errorf("cannot scale y data: %w", err)
+			}
+			scaledResults[i] = splitRes{x: res.x, y: yF / u.factor}
+		}
+		scaled[groupName] = scaledResults
+	}
+	return scaled, nil
+}
@@ -0,0 +1,109 @@
+package plot
+
+import (
+	"reflect"
+	"testing"
+)
+
+var selectUnitTests = map[string]struct {
+	splitGrouped map[string][]splitRes
+	units        []unitScale
+	unitName     string
+	expectedUnit unitScale
+	expectErr    bool
+}{
+	"time,ns": {
+		splitGrouped: map[string][]splitRes{
+			"": []splitRes{{x: 1, y: float64(10)}, {x: 2, y: float64(999)}},
+		},
+		units:        timeUnits,
+		expectedUnit: unitScale{name: "ns/op", factor: 1},
+	},
+	"time,ms": {
+		splitGrouped: map[string][]splitRes{
+			"a": []splitRes{{x: 1, y: float64(10)}},
+			"b": []splitRes{{x: 1, y: float64(2.5e6)}},
+		},
+		units:        timeUnits,
+		expectedUnit: unitScale{name: "ms/op", factor: 1e6},
+	},
+	"time,s": {
+		splitGrouped: map[string][]splitRes{
+			"": []splitRes{{x: 1, y: float64(5e12)}},
+		},
+		units:        timeUnits,
+		expectedUnit: unitScale{name: "s/op", factor: 1e9},
+	},
+	"bytes,KiB": {
+		splitGrouped: map[string][]splitRes{
+			"": []splitRes{{x: 1, y: uint64(1024)}, {x: 2, y: uint64(2048)}},
+		},
+		units:        allocBytesUnits,
+		expectedUnit: unitScale{name: "KiB/op", factor: 1 << 10},
+	},
+	"all_zero": {
+		splitGrouped: map[string][]splitRes{
+			"": []splitRes{{x: 1, y: uint64(0)}},
+		},
+		units:        numAllocsUnits,
+		expectedUnit: unitScale{name: "allocs/op", factor: 1},
+	},
+	"forced_unit": {
+		splitGrouped: map[string][]splitRes{
+			"": []splitRes{{x: 1, y: float64(2.5e6)}},
+		},
+		units:        timeUnits,
+		unitName:     "µs/op",
+		expectedUnit: unitScale{name: "µs/op", factor: 1e3},
+	},
+	"unknown_forced_unit": {
+		splitGrouped: map[string][]splitRes{
+			"": []splitRes{{x: 1, y: float64(2.5e6)}},
+		},
+		units:     timeUnits,
+		unitName:  "KiB/op",
+		expectErr: true,
+	},
+}
+
+func TestSelectUnit(t *testing.T) {
+	for testName, testCase := range selectUnitTests {
+		t.Run(testName, func(t *testing.T) {
+			u, err := selectUnit(testCase.splitGrouped, testCase.units, testCase.unitName)
+			if err != nil {
+				if !testCase.expectErr {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+
+			if testCase.expectErr {
+				t.Errorf("unexpectedly no error")
+			}
+
+			if !reflect.DeepEqual(u, testCase.expectedUnit) {
+				t.Errorf("unexpected unit (expected=%v, actual=%v)", testCase.expectedUnit, u)
+			}
+		})
+	}
+}
+
+func TestScaleSplitGrouped(t *testing.T) {
+	var (
+		splitGrouped = map[string][]splitRes{
+			"": []splitRes{{x: 1, y: uint64(512)}, {x: 2, y: uint64(2048)}},
+		}
+		expectedScaled = map[string][]splitRes{
+			"": []splitRes{{x: 1, y: 0.5}, {x: 2, y: float64(2)}},
+		}
+	)
+
+	scaled, err := scaleSplitGrouped(splitGrouped, unitScale{name: "KiB/op", factor: 1 << 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !reflect.DeepEqual(scaled, expectedScaled) {
+		t.Errorf("unexpected scaled results\nexpected:\n%#v\nactual:\n%#v", expectedScaled, scaled)
+	}
+}
@@ -20,6 +20,57 @@ The input is the output of a go benchmark.
 \`benchplot -bench \${bench} -x \${x_var} \${FILE}\`
 Where \`\${FILE}\` is the path to a file containing the output of a go benchmark (if empty or \`"-"\` stdin is used), \`\${bench}\` is the name of the benchmark to plot, and \`\${x_var}\` is the name of the variable to use for the x-axis of the plot.
 
+Multiple files can be provided to compare results (e.g. before and after a change), in which case the results from each file are plotted as a separate series labeled by the file name (or the corresponding \`-label\`):
+\`benchplot -bench \${bench} -x \${x_var} -label old -label new old.txt new.txt\`
+
+Every benchmark in the input can be plotted at once with \`-bench all\`, in which case each benchmark is saved to a separate file named using the \`-o\` template (benchmarks without the requested \`\${x_var}\` are skipped):
+\`benchplot -bench all -x \${x_var} -o '{{.Bench}}_{{.X}}.svg' \${FILE}\`
+
+Multiple benchmarks sharing the same \`\${x_var}\` can be plotted on the same figure by repeating \`-bench\`, in which case each benchmark is plotted as a separate series:
+\`benchplot -bench BenchmarkMapGet -bench BenchmarkSyncMapGet -x \${x_var} \${FILE}\`
+
+The data behind a figure can be written as CSV or JSON, either instead of the figure by giving \`-o\` a \`.csv\` or \`.json\` extension or alongside it with \`-data-out\`:
+\`benchplot -bench \${bench} -x \${x_var} -data-out \${bench}.csv \${FILE}\`
+
+Giving \`-o\` a \`.html\` extension writes a self-contained interactive page instead of an image, with tooltips showing the value of each point and legend entries which toggle each series when clicked.
+
+When working on a remote machine, \`-term\` (or \`-o -\`) draws scatter, line, and bar plots directly in the terminal, in which case \`-width\` and \`-height\` are the number of columns and rows:
+\`benchplot -bench \${bench} -x \${x_var} -term \${FILE}\`
+
+The \`fit\` plot type fits each group against common complexity classes (O(1), O(log n), O(n), O(n log n), and O(n²)) and draws the best fit in the color of its group, with the chosen class and its R² shown in the legend. Each class is fit as y = a + b·f(x) by least squares, so fixed overhead (e.g. setup time) is captured by the intercept a rather than the growth of f(x). Both coefficients of each fit are also written to stdout:
+\`benchplot -bench \${bench} -x n -plots scatter -plots fit \${FILE}\`
+
+When comparing results, \`-significance\` tests whether each group differs from its baseline at each x using the Mann-Whitney U test. Significant differences are drawn as filled points and the rest as gray hollow points, and a summary of each change and its p-value is printed. The baseline is the group given by \`-compare-to\` (or \`-normalize-to\`), otherwise the first input file (or the first \`-bench\` when plotting multiple benchmarks), or the first of exactly two groups:
+\`benchplot -bench \${bench} -x \${x_var} -significance 0.05 -label old -label new old.txt new.txt\`
+
+A single slow run (e.g. due to a GC pause) can skew averages, so \`-outliers\` discards outliers among the results of each group at each x before plotting, using either the interquartile range (\`iqr\`) or median absolute deviation (\`mad\`) rule. The number of discarded points is printed, and \`-show-outliers\` still displays them in scatter plots in a muted style:
+\`benchplot -bench \${bench} -x \${x_var} -outliers iqr -show-outliers -plots scatter -plots avg_line \${FILE}\`
+
+The \`agg_line\` plot type draws a line through an aggregate of the results at each x other than the average, selected with \`-agg\` (\`mean\`, \`median\`, \`geomean\`, \`min\`, \`max\`, or a percentile such as \`p90\`), with the aggregate shown in the legend when it is the first plot type. The default plots for numeric data draw a \`mean\` \`agg_line\` unless \`-agg\` selects another aggregate, which cannot be combined with \`avg_line\` since it always shows the mean:
+\`benchplot -bench \${bench} -x \${x_var} -agg median \${FILE}\`
+
+The y-axis may be an arithmetic expression over the outputs and numeric inputs of each result, for example the time per element or bytes per allocation:
+\`benchplot -bench \${bench} -x n -y 'time / n' \${FILE}\`
+
+Sub-benchmark names which are not of the form \`var_name=var_value\` (e.g. \`BenchmarkFoo/1024/parallel\`) can still be plotted, either by referring to each component by position as \`sub[0]\`, \`sub[1]\`, etc. or by parsing the name with a regular expression whose named captures become variables. Either can be used anywhere a variable can, including \`-group-by\` and \`-filter-by\`:
+\`benchplot -bench BenchmarkFoo -x 'sub[0]' -group-by 'sub[1]' \${FILE}\`
+\`benchplot -bench BenchmarkFoo -name-pattern '^(?P<size>\\d+)/(?P<mode>\\w+)\$' -x size -group-by mode \${FILE}\`
+
+The value of GOMAXPROCS for each result (the \`-N\` suffix Go appends to benchmark names, e.g. when run with \`-cpu 1,2,4,8\`) is available as the variable \`procs\`, so a CPU sweep can be plotted as a parallel scaling chart:
+\`benchplot -bench \${bench} -x procs -group-by \${var} \${FILE}\`
+
+The \`scaling\` plot type shows how well each group scales with GOMAXPROCS. It plots the speedup T(1)/T(p) of the average y value at each number of procs p alongside the ideal linear speedup, with the efficiency (speedup/p) at the most procs shown in the legend. The speedup and efficiency at each p are also written to stdout. The y value must be \`time\` or a rate such as \`mem_by_time\` or a custom \`/s\` metric, for which the speedup is R(p)/R(1) instead. Every group must have results with procs=1 to use as the baseline, and scaling plots cannot be combined with other plot types, \`-significance\` or \`-normalize-to\`:
+\`benchplot -bench \${bench} -x procs -group-by \${var} -plots scaling \${FILE}\`
+
+The output of \`go test -json -bench\` is detected and read directly, including result lines split across multiple events. The detection can be overridden with \`-input-format\`:
+\`go test -json -run xxx -bench \${bench} | benchplot -bench \${bench} -x \${var}\`
+
+Results from other harnesses may be read from CSV (detected by the \`.csv\` extension) or JSON records, with one record per benchmark run. By default the \`name\` column is the benchmark name, columns named after a unit (e.g. \`ns/op\`, \`B/op\`) are outputs, and all other columns are inputs. The role of each column can be set with \`-column\`:
+\`benchplot -bench \${bench} -x size -group-by impl -column bench=name -column time_ns=ns/op \${FILE}.csv\`
+
+The \`goos\`, \`goarch\`, \`pkg\`, and \`cpu\` lines printed by \`go test -bench\` are shown below the title, and may be used to group or filter results like inputs. This makes it easy to compare results from different machines:
+\`benchplot -bench \${bench} -x \${var} -group-by goarch amd64.txt arm64.txt\`
+
 Full flag set:
 \`\`\`
 $USAGE
@@ -33,5 +84,5 @@ go test ./benchmark -run ! -bench BenchmarkGroupResults -count 3 -race | benchpl
 ![benchgroupres](https://github.com/ShawnROGrady/benchplot/blob/master/assets/BenchmarkGroupResults.png)
 
 ## Next Steps
-Right now the main focus is bringing the feature set to parity with my [initial implementation of this tool](https://github.com/ShawnROGrady/gobenchplot) which used Python and matplotlib. This includes supporting bar charts (which would be the default if the provided \`\${x_var}\` had non-numeric data) and filtering of data.
+Right now the main focus is bringing the feature set to parity with my [initial implementation of this tool](https://github.com/ShawnROGrady/gobenchplot) which used Python and matplotlib. Bar charts are supported and are the default if the provided \`\${x_var}\` has non-numeric data.
 EOF
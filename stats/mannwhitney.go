@@ -0,0 +1,153 @@
+// Package stats contains statistical tests used to compare the
+// results of benchmarks.
+package stats
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// maxExactSamples is the maximum combined size of the samples for
+// which the exact distribution of U is computed.
+const maxExactSamples = 50
+
+var (
+	// ErrSampleSize is returned when either sample is empty.
+	ErrSampleSize = errors.New("sample is too small")
+
+	// ErrSamplesEqual is returned when every value of both samples
+	// is equal, in which case the samples cannot be distinguished.
+	ErrSamplesEqual = errors.New("all samples are equal")
+)
+
+// MannWhitneyUResult is the result of a Mann-Whitney U test.
+type MannWhitneyUResult struct {
+	N1, N2 int     // the size of each sample
+	U      float64 // the U statistic of the first sample
+	P      float64 // the two-sided p-value
+}
+
+// MannWhitneyUTest tests the null hypothesis that the two samples
+// come from the same distribution, against the alternative that
+// values from one tend to be larger than values from the other.
+//
+// As in benchstat, the p-value is exact for small samples without
+// ties. Otherwise it is computed using the normal approximation
+// with corrections for ties and continuity.
+func MannWhitneyUTest(x1, x2 []float64) (MannWhitneyUResult, error) {
+	n1, n2 := len(x1), len(x2)
+	if n1 == 0 || n2 == 0 {
+		return MannWhitneyUResult{}, ErrSampleSize
+	}
+
+	ranks, tieCorrection := rank(x1, x2)
+	var r1 float64
+	for _, r := range ranks[:n1] {
+		r1 += r
+	}
+	u1 := r1 - float64(n1*(n1+1))/2
+	res := MannWhitneyUResult{N1: n1, N2: n2, U: u1}
+
+	if tieCorrection == 0 && n1+n2 <= maxExactSamples {
+		res.P = exactP(n1, n2, u1)
+		return res, nil
+	}
+
+	var (
+		n     = float64(n1 + n2)
+		mu    = float64(n1*n2) / 2
+		sigma = math.Sqrt(float64(n1*n2) / 12 * ((n + 1) - tieCorrection/(n*(n-1))))
+	)
+	if sigma == 0 {
+		return MannWhitneyUResult{}, ErrSamplesEqual
+	}
+	z := (math.Abs(u1-mu) - 0.5) / sigma
+	if z < 0 {
+		z = 0
+	}
+	res.P = math.Min(1, math.Erfc(z/math.Sqrt2))
+	return res, nil
+}
+
+// rank returns the rank of each value of the combined samples, with
+// tied values given their average rank, along with the sum of t³-t
+// over each group of t tied values.
+func rank(x1, x2 []float64) ([]float64, float64) {
+	type value struct {
+		v   float64
+		pos int
+	}
+	values := make([]value, 0, len(x1)+len(x2))
+	for i, v := range x1 {
+		values = append(values, value{v: v, pos: i})
+	}
+	for i, v := range x2 {
+		values = append(values, value{v: v, pos: len(x1) + i})
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i].v < values[j].v })
+
+	var (
+		ranks         = make([]float64, len(values))
+		tieCorrection float64
+	)
+	for i := 0; i < len(values); {
+		j := i + 1
+		for j < len(values) && values[j].v == values[i].v {
+			j++
+		}
+		// ranks are 1-based, so the average of ranks i+1..j
+		avg := float64(i+j+1) / 2
+		for k := i; k < j; k++ {
+			ranks[values[k].pos] = avg
+		}
+		if t := float64(j - i); t > 1 {
+			tieCorrection += t*t*t - t
+		}
+		i = j
+	}
+	return ranks, tieCorrection
+}
+
+// exactP returns the exact two-sided p-value of the U statistic of
+// the first sample, assuming no ties.
+func exactP(n1, n2 int, u float64) float64 {
+	// counts[i][j][k] is the number of orderings of samples of size
+	// i and j in which the first sample has U = k
+	counts := make([][][]float64, n1+1)
+	for i := range counts {
+		counts[i] = make([][]float64, n2+1)
+		for j := range counts[i] {
+			counts[i][j] = make([]float64, i*j+1)
+			if i == 0 || j == 0 {
+				counts[i][j][0] = 1
+				continue
+			}
+			for k := range counts[i][j] {
+				// the largest value is either from the first sample,
+				// which is then larger than all j values of the
+				// second, or from the second sample
+				if k >= j {
+					counts[i][j][k] += counts[i-1][j][k-j]
+				}
+				if k < len(counts[i][j-1]) {
+					counts[i][j][k] += counts[i][j-1][k]
+				}
+			}
+		}
+	}
+
+	var (
+		dist  = counts[n1][n2]
+		small = math.Min(u, float64(n1*n2)-u)
+		total float64
+		tail  float64
+	)
+	for k, c := range dist {
+		total += c
+		if float64(k) <= small {
+			tail += c
+		}
+	}
+	return math.Min(1, 2*tail/total)
+}
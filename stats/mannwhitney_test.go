@@ -0,0 +1,81 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+var mannWhitneyUTests = map[string]struct {
+	x1, x2    []float64
+	expectedU float64
+	expectedP float64
+	expectErr error
+}{
+	"exact,separated": {
+		x1:        []float64{1, 2, 3},
+		x2:        []float64{4, 5, 6},
+		expectedU: 0,
+		expectedP: 0.1,
+	},
+	"exact,smallest_possible_n2": {
+		x1:        []float64{10, 11},
+		x2:        []float64{1, 2},
+		expectedU: 4,
+		expectedP: 1.0 / 3,
+	},
+	"exact,interleaved": {
+		x1:        []float64{1, 3, 5, 7},
+		x2:        []float64{2, 4, 6, 8},
+		expectedU: 6,
+		expectedP: 0.6857142857142857,
+	},
+	"exact,larger": {
+		x1:        []float64{0.8, 0.83, 1.89, 1.04, 1.45, 1.38, 1.91, 1.64, 0.73, 1.46},
+		x2:        []float64{1.15, 0.88, 0.9, 0.74, 1.21, 0.72, 0.71, 0.66, 0.62, 0.6},
+		expectedU: 85,
+		expectedP: 0.006841455757864426,
+	},
+	"approx,ties": {
+		x1:        []float64{1, 2, 2, 3, 3, 3},
+		x2:        []float64{3, 4, 4, 5, 5, 6},
+		expectedU: 1.5,
+		expectedP: 0.00873276851253925,
+	},
+	"all_equal": {
+		x1:        []float64{1, 1},
+		x2:        []float64{1, 1},
+		expectErr: ErrSamplesEqual,
+	},
+	"empty": {
+		x1:        []float64{},
+		x2:        []float64{1},
+		expectErr: ErrSampleSize,
+	},
+}
+
+func TestMannWhitneyUTest(t *testing.T) {
+	for testName, testCase := range mannWhitneyUTests {
+		t.Run(testName, func(t *testing.T) {
+			res, err := MannWhitneyUTest(testCase.x1, testCase.x2)
+			if err != nil {
+				if err != testCase.expectErr {
+					t.Errorf("unexpected error (expected = %v, actual = %s)", testCase.expectErr, err)
+				}
+				return
+			}
+			if testCase.expectErr != nil {
+				t.Fatalf("unexpectedly no error")
+			}
+
+			if res.U != testCase.expectedU {
+				t.Errorf("unexpected U (expected = %v, actual = %v)", testCase.expectedU, res.U)
+			}
+			if math.Abs(res.P-testCase.expectedP) > 1e-12 {
+				t.Errorf("unexpected p-value (expected = %v, actual = %v)", testCase.expectedP, res.P)
+			}
+			if res.N1 != len(testCase.x1) || res.N2 != len(testCase.x2) {
+				t.Errorf("unexpected sample sizes (expected = %d+%d, actual = %d+%d)", len(testCase.x1), len(testCase.x2), res.N1, res.N2)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package term
+
+import (
+	"strings"
+)
+
+// brailleBlank is the braille character with no dots raised.
+const brailleBlank = 0x2800
+
+// brailleDots are the bits of each dot within a braille character,
+// indexed by [row][column].
+var brailleDots = [4][2]rune{
+	{0x01, 0x08},
+	{0x02, 0x10},
+	{0x04, 0x20},
+	{0x40, 0x80},
+}
+
+// canvas is a grid of braille characters, each of which holds
+// 2 columns and 4 rows of dots.
+type canvas struct {
+	cols, rows int
+	cells      []rune
+	colors     []int
+}
+
+func newCanvas(cols, rows int) *canvas {
+	c := &canvas{
+		cols:   cols,
+		rows:   rows,
+		cells:  make([]rune, cols*rows),
+		colors: make([]int, cols*rows),
+	}
+	for i := range c.cells {
+		c.cells[i] = brailleBlank
+		c.colors[i] = -1
+	}
+	return c
+}
+
+func (c *canvas) dotCols() int { return c.cols * 2 }
+
+func (c *canvas) dotRows() int { return c.rows * 4 }
+
+// set raises the dot at (x, y), with y increasing downward. The cell
+// containing the dot takes the color of the most recent dot.
+func (c *canvas) set(x, y, color int) {
+	if x < 0 || y < 0 || x >= c.dotCols() || y >= c.dotRows() {
+		return
+	}
+	i := (y/4)*c.cols + x/2
+	c.cells[i] |= brailleDots[y%4][x%2]
+	c.colors[i] = color
+}
+
+// fill raises every dot of the cell containing the dot at (x, y).
+func (c *canvas) fill(x, y, color int) {
+	if x < 0 || y < 0 || x >= c.dotCols() || y >= c.dotRows() {
+		return
+	}
+	i := (y/4)*c.cols + x/2
+	c.cells[i] = brailleBlank | 0xff
+	c.colors[i] = color
+}
+
+// line raises the dots along the line from (x0, y0) to (x1, y1).
+func (c *canvas) line(x0, y0, x1, y1, color int) {
+	var (
+		dx, dy = abs(x1 - x0), -abs(y1 - y0)
+		sx, sy = 1, 1
+		err    = dx + dy
+	)
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+	for {
+		c.set(x0, y0, color)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y0 += sy
+		}
+	}
+}
+
+// row returns the characters of the row, each colored by colorize.
+func (c *canvas) row(row int, colorize func(text string, color int) string) string {
+	var b strings.Builder
+	for i := row * c.cols; i < (row+1)*c.cols; i++ {
+		if c.cells[i] == brailleBlank {
+			b.WriteRune(' ')
+			continue
+		}
+		b.WriteString(colorize(string(c.cells[i]), c.colors[i]))
+	}
+	return b.String()
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,587 @@
+// Package term contains a Plotter implementation which draws plots
+// in the terminal using Unicode braille characters.
+package term
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ShawnROGrady/benchplot/plot/plotter"
+)
+
+// ErrUnsupportedPlot is returned when plotting a kind of plot which
+// cannot be drawn in the terminal.
+var ErrUnsupportedPlot = errors.New("plot not supported in terminal")
+
+// numYLabels is the number of labeled rows along the y axis.
+const numYLabels = 5
+
+// colors are the ANSI color codes used for each group in turn.
+var colors = []int{31, 32, 34, 33, 35, 36}
+
+// mutedColor is the ANSI color code of points which do not differ
+// significantly from their baseline.
+const mutedColor = 90
+
+// mutedGroup is the color index used to draw muted points.
+const mutedGroup = -2
+
+// barEighths are the block characters filling each eighth of a cell
+// from the left, used to draw the end of a bar.
+var barEighths = []rune(" ▏▎▍▌▋▊▉█")
+
+// series is the data of a single group for a single plot.
+type series struct {
+	group string
+	line  bool
+	x     []float64
+	y     []float64
+
+	// yLow and yHigh are the absolute bounds of error bars, if any.
+	yLow  []float64
+	yHigh []float64
+
+	// significant marks which points of a significance plot differ
+	// significantly from their baseline, if any.
+	significant []bool
+
+	// outliers is whether the points were discarded as outliers.
+	outliers bool
+}
+
+// bars is the data of a single group for a bar plot.
+type bars struct {
+	group      string
+	categories []string
+	values     []float64
+}
+
+// Plotter records the data of each plot to implement Plotter.
+// The figure is drawn when written.
+type Plotter struct {
+	// Color enables ANSI colors for each group.
+	Color bool
+
+	title    string
+	subtitle string
+	xLabel   string
+	yLabel   string
+	xScale   plotter.Scale
+	yScale   plotter.Scale
+	series   []series
+	bars     []bars
+	groups   []string
+
+	// alpha is the significance level of a significance plot, or 0
+	// if there is none.
+	alpha float64
+}
+
+// PlotScatter creates a scatter plot of the specified data.
+func (t *Plotter) PlotScatter(data map[string]plotter.NumericData, title, xLabel, yLabel string, includeLegend bool) error {
+	t.setLabels(title, xLabel, yLabel)
+
+	// use sorted keys for consistent iteration order
+	groupNames := make([]string, len(data))
+	j := 0
+	for k := range data {
+		groupNames[j] = k
+		j++
+	}
+	sort.Strings(groupNames)
+
+	for _, groupName := range groupNames {
+		groupData := data[groupName]
+		if err := t.checkScales(groupData.X, groupData.Y); err != nil {
+			return err
+		}
+		t.addSeries(series{group: groupName, x: groupData.X, y: groupData.Y})
+	}
+	return nil
+}
+
+// PlotLine creates a line plot of the specified data.
+func (t *Plotter) PlotLine(data map[string]plotter.NumericData, title, xLabel, yLabel string, includeLegend bool) error {
+	t.setLabels(title, xLabel, yLabel)
+
+	// use sorted keys for consistent iteration order
+	groupNames := make([]string, len(data))
+	j := 0
+	for k := range data {
+		groupNames[j] = k
+		j++
+	}
+	sort.Strings(groupNames)
+
+	for _, groupName := range groupNames {
+		groupData := data[groupName]
+		if err := t.checkScales(groupData.X, groupData.Y); err != nil {
+			return err
+		}
+		t.addSeries(series{group: groupName, line: true, x: groupData.X, y: groupData.Y})
+	}
+	return nil
+}
+
+// PlotBar creates a bar plot of the specified data. The bars are
+// drawn horizontally, with the categories listed down the left edge.
+func (t *Plotter) PlotBar(data map[string]plotter.CategoricalData, title, xLabel, yLabel string, includeLegend bool) error {
+	t.setLabels(title, xLabel, yLabel)
+
+	// use sorted keys for consistent iteration order
+	groupNames := make([]string, len(data))
+	j := 0
+	for k := range data {
+		groupNames[j] = k
+		j++
+	}
+	sort.Strings(groupNames)
+
+	for _, groupName := range groupNames {
+		groupData := data[groupName]
+		if err := t.yScale.Check("y", groupData.Y...); err != nil {
+			return err
+		}
+		t.addGroup(groupName)
+		t.bars = append(t.bars, bars{group: groupName, categories: groupData.X, values: groupData.Y})
+	}
+	return nil
+}
+
+// PlotErrorBars creates a line plot of the specified data with
+// error bars at each point.
+func (t *Plotter) PlotErrorBars(data map[string]plotter.ErrorData, title, xLabel, yLabel string, includeLegend bool) error {
+	t.setLabels(title, xLabel, yLabel)
+
+	// use sorted keys for consistent iteration order
+	groupNames := make([]string, len(data))
+	j := 0
+	for k := range data {
+		groupNames[j] = k
+		j++
+	}
+	sort.Strings(groupNames)
+
+	for _, groupName := range groupNames {
+		groupData := data[groupName]
+		s := series{
+			group: groupName,
+			line:  true,
+			x:     groupData.X,
+			y:     groupData.Y,
+			yLow:  make([]float64, len(groupData.X)),
+			yHigh: make([]float64, len(groupData.X)),
+		}
+		for i := range groupData.X {
+			s.yLow[i] = groupData.Y[i] - groupData.YErrLow[i]
+			s.yHigh[i] = groupData.Y[i] + groupData.YErrHigh[i]
+		}
+		if err := t.checkScales(s.x, s.yLow); err != nil {
+			return err
+		}
+		t.addSeries(s)
+	}
+	return nil
+}
+
+// PlotBox is not supported in the terminal.
+func (t *Plotter) PlotBox(data map[string]plotter.DistributionData, title, xLabel, yLabel string, includeLegend bool) error {
+	return ErrUnsupportedPlot
+}
+
+// PlotSignificance marks the points of the specified data, with points
+// that differ significantly from the baseline drawn as full cells and
+// the rest as single gray dots.
+func (t *Plotter) PlotSignificance(data map[string]plotter.SignificanceData, alpha float64, title, xLabel, yLabel string) error {
+	t.setLabels(title, xLabel, yLabel)
+	t.alpha = alpha
+
+	// use sorted keys for consistent iteration order
+	groupNames := make([]string, len(data))
+	j := 0
+	for k := range data {
+		groupNames[j] = k
+		j++
+	}
+	sort.Strings(groupNames)
+
+	for _, groupName := range groupNames {
+		groupData := data[groupName]
+		if err := t.checkScales(groupData.X, groupData.Y); err != nil {
+			return err
+		}
+		s := series{
+			group:       groupName,
+			x:           groupData.X,
+			y:           groupData.Y,
+			significant: make([]bool, len(groupData.X)),
+		}
+		for i, p := range groupData.P {
+			s.significant[i] = p < alpha
+		}
+		t.addSeries(s)
+	}
+	return nil
+}
+
+// PlotOutliers draws points discarded as outliers as single gray dots.
+func (t *Plotter) PlotOutliers(data map[string]plotter.NumericData, title, xLabel, yLabel string) error {
+	t.setLabels(title, xLabel, yLabel)
+
+	// use sorted keys for consistent iteration order
+	groupNames := make([]string, len(data))
+	j := 0
+	for k := range data {
+		groupNames[j] = k
+		j++
+	}
+	sort.Strings(groupNames)
+
+	for _, groupName := range groupNames {
+		groupData := data[groupName]
+		if err := t.checkScales(groupData.X, groupData.Y); err != nil {
+			return err
+		}
+		t.addSeries(series{group: groupName, x: groupData.X, y: groupData.Y, outliers: true})
+	}
+	return nil
+}
+
+// SetScales sets the scales of the x and y axes.
+func (t *Plotter) SetScales(xScale, yScale plotter.Scale) error {
+	for _, scale := range []plotter.Scale{xScale, yScale} {
+		switch scale {
+		case plotter.LinearScale, plotter.Log2Scale, plotter.Log10Scale, "":
+		default:
+			return fmt.Errorf("unknown scale: %s", scale)
+		}
+	}
+	t.xScale, t.yScale = xScale, yScale
+	return nil
+}
+
+// SetSubtitle sets the line drawn below the title.
+func (t *Plotter) SetSubtitle(subtitle string) error {
+	t.subtitle = subtitle
+	return nil
+}
+
+// Write draws the figure to w, using at most the specified number
+// of columns and rows.
+func (t *Plotter) Write(w io.Writer, cols, rows int) error {
+	if len(t.bars) != 0 {
+		if len(t.series) != 0 {
+			return errors.New("bar plots cannot be drawn with other plots in the terminal")
+		}
+		return t.writeBars(w, cols, rows)
+	}
+
+	var (
+		xs, ys = t.values()
+		xAxis  = newAxis(t.xScale, xs)
+		yAxis  = newAxis(t.yScale, ys)
+	)
+
+	// the y labels are drawn at evenly spaced rows on the left
+	yLabels := make([]string, numYLabels)
+	labelWidth := 0
+	for i := range yLabels {
+		yLabels[i] = formatValue(yAxis.value(1 - float64(i)/float64(numYLabels-1)))
+		if len(yLabels[i]) > labelWidth {
+			labelWidth = len(yLabels[i])
+		}
+	}
+
+	var hasSignificant, hasMuted, hasOutliers bool
+	for _, s := range t.series {
+		for _, significant := range s.significant {
+			hasSignificant = hasSignificant || significant
+			hasMuted = hasMuted || !significant
+		}
+		hasOutliers = hasOutliers || (s.outliers && len(s.x) != 0)
+	}
+	legendRows := len(t.groups)
+	for _, has := range []bool{hasSignificant, hasMuted, hasOutliers} {
+		if has {
+			legendRows++
+		}
+	}
+
+	// title, y label, x axis, x ticks, x label, and legend
+	plotRows := rows - 5 - legendRows
+	if t.subtitle != "" {
+		plotRows--
+	}
+	plotCols := cols - labelWidth - 2
+	if plotRows < numYLabels || plotCols < 2 {
+		return fmt.Errorf("%d columns and %d rows is too small for a terminal plot", cols, rows)
+	}
+
+	c := newCanvas(plotCols, plotRows)
+	for _, s := range t.series {
+		color := t.groupIndex(s.group)
+		var prevX, prevY int
+		for i := range s.x {
+			x := int(math.Round(xAxis.frac(s.x[i]) * float64(c.dotCols()-1)))
+			y := int(math.Round((1 - yAxis.frac(s.y[i])) * float64(c.dotRows()-1)))
+			switch {
+			case s.significant != nil && s.significant[i]:
+				c.fill(x, y, color)
+			case s.significant != nil, s.outliers:
+				c.set(x, y, mutedGroup)
+			case s.line && i > 0:
+				c.line(prevX, prevY, x, y, color)
+			default:
+				c.set(x, y, color)
+			}
+			if s.yLow != nil {
+				low := int(math.Round((1 - yAxis.frac(s.yLow[i])) * float64(c.dotRows()-1)))
+				high := int(math.Round((1 - yAxis.frac(s.yHigh[i])) * float64(c.dotRows()-1)))
+				c.line(x, low, x, high, color)
+			}
+			prevX, prevY = x, y
+		}
+	}
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "%s\n", center(t.title, cols))
+	if t.subtitle != "" {
+		fmt.Fprintf(&out, "%s\n", center(t.subtitle, cols))
+	}
+	fmt.Fprintf(&out, "%s\n", t.yLabel)
+	for row := 0; row < plotRows; row++ {
+		label := ""
+		for i := range yLabels {
+			if row == int(math.Round(float64(i)/float64(numYLabels-1)*float64(plotRows-1))) {
+				label = yLabels[i]
+			}
+		}
+		fmt.Fprintf(&out, "%*s ┤%s\n", labelWidth, label, c.row(row, t.colorize))
+	}
+	fmt.Fprintf(&out, "%*s └%s\n", labelWidth, "", strings.Repeat("─", plotCols))
+
+	fmt.Fprintf(&out, "%*s  %s\n", labelWidth, "", ticks(xAxis, plotCols))
+	fmt.Fprintf(&out, "%*s  %s\n", labelWidth, "", center(t.xLabel, plotCols))
+
+	t.writeGroups(&out)
+	if hasSignificant {
+		fmt.Fprintf(&out, "⣿ p < %v\n", t.alpha)
+	}
+	if hasMuted {
+		fmt.Fprintf(&out, "%s not significant\n", t.colorize(string(brailleBlank+brailleDots[1][0]), mutedGroup))
+	}
+	if hasOutliers {
+		fmt.Fprintf(&out, "%s outliers\n", t.colorize(string(brailleBlank+brailleDots[1][0]), mutedGroup))
+	}
+
+	_, err := io.WriteString(w, out.String())
+	return err
+}
+
+// writeBars draws the recorded bar plots to w, with a row for each
+// group having a value in each category.
+func (t *Plotter) writeBars(w io.Writer, cols, rows int) error {
+	var (
+		groupCategories = make([][]string, len(t.bars))
+		vals            = []float64{}
+	)
+	for i, b := range t.bars {
+		groupCategories[i] = b.categories
+		vals = append(vals, b.values...)
+	}
+	categories := plotter.MergeCategories(groupCategories)
+
+	labelWidth := 0
+	for _, category := range categories {
+		if n := len([]rune(category)); n > labelWidth {
+			labelWidth = n
+		}
+	}
+
+	// bars start from 0 unless the axis is logarithmic
+	if !t.yScale.IsLog() {
+		vals = append(vals, 0)
+	}
+	var (
+		valAxis = newAxis(t.yScale, vals)
+		base    = 0.0
+	)
+	if !t.yScale.IsLog() {
+		base = valAxis.frac(0)
+	}
+
+	barRows := 0
+	for _, b := range t.bars {
+		barRows += len(b.values)
+	}
+	// title, x label, axis, ticks, y label, and legend
+	plotCols := cols - labelWidth - 2
+	available := rows - 5 - len(t.groups)
+	if t.subtitle != "" {
+		available--
+	}
+	if barRows > available || plotCols < 2 {
+		return fmt.Errorf("%d columns and %d rows is too small for a terminal plot", cols, rows)
+	}
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "%s\n", center(t.title, cols))
+	if t.subtitle != "" {
+		fmt.Fprintf(&out, "%s\n", center(t.subtitle, cols))
+	}
+	fmt.Fprintf(&out, "%s\n", t.xLabel)
+	for _, category := range categories {
+		label := category
+		for _, b := range t.bars {
+			for i, c := range b.categories {
+				if c != category {
+					continue
+				}
+				bar := barCells(base*float64(plotCols), valAxis.frac(b.values[i])*float64(plotCols), plotCols)
+				fmt.Fprintf(&out, "%*s ┤%s\n", labelWidth, label, t.colorize(bar, t.groupIndex(b.group)))
+				label = ""
+			}
+		}
+	}
+	fmt.Fprintf(&out, "%*s └%s\n", labelWidth, "", strings.Repeat("─", plotCols))
+	fmt.Fprintf(&out, "%*s  %s\n", labelWidth, "", ticks(valAxis, plotCols))
+	fmt.Fprintf(&out, "%*s  %s\n", labelWidth, "", center(t.yLabel, plotCols))
+	t.writeGroups(&out)
+
+	_, err := io.WriteString(w, out.String())
+	return err
+}
+
+// writeGroups writes the legend entry of each group.
+func (t *Plotter) writeGroups(out *strings.Builder) {
+	for i, group := range t.groups {
+		name := group
+		if name == "" {
+			name = t.title
+		}
+		fmt.Fprintf(out, "%s %s\n", t.colorize("⣿", i), name)
+	}
+}
+
+func (t *Plotter) setLabels(title, xLabel, yLabel string) {
+	t.title, t.xLabel, t.yLabel = title, xLabel, yLabel
+}
+
+// addSeries records the series, giving each distinct group a
+// consistent color and legend entry across plots.
+func (t *Plotter) addSeries(s series) {
+	t.addGroup(s.group)
+	t.series = append(t.series, s)
+}
+
+// addGroup records the group if it has not been plotted before.
+func (t *Plotter) addGroup(group string) {
+	for _, existing := range t.groups {
+		if existing == group {
+			return
+		}
+	}
+	t.groups = append(t.groups, group)
+}
+
+// groupIndex returns the position of the group among every plotted
+// group, used to assign its color.
+func (t *Plotter) groupIndex(group string) int {
+	for i, existing := range t.groups {
+		if existing == group {
+			return i
+		}
+	}
+	return 0
+}
+
+// values returns every drawn x and y value.
+func (t *Plotter) values() ([]float64, []float64) {
+	xs, ys := []float64{}, []float64{}
+	for _, s := range t.series {
+		xs = append(xs, s.x...)
+		ys = append(ys, s.y...)
+		ys = append(ys, s.yLow...)
+		ys = append(ys, s.yHigh...)
+	}
+	return xs, ys
+}
+
+// colorize wraps the text in the ANSI color of the group, if
+// colors are enabled.
+func (t *Plotter) colorize(text string, group int) string {
+	if t.Color && group == mutedGroup {
+		return fmt.Sprintf("\x1b[%dm%s\x1b[0m", mutedColor, text)
+	}
+	if !t.Color || group < 0 {
+		return text
+	}
+	return fmt.Sprintf("\x1b[%dm%s\x1b[0m", colors[group%len(colors)], text)
+}
+
+// checkScales verifies the data can be displayed with the
+// scales of each axis.
+func (t *Plotter) checkScales(xs, ys []float64) error {
+	if err := t.xScale.Check("x", xs...); err != nil {
+		return err
+	}
+	return t.yScale.Check("y", ys...)
+}
+
+// ticks returns the labels of the minimum, middle, and maximum values
+// of the axis, spaced across the width.
+func ticks(a axis, width int) string {
+	var (
+		minLabel = formatValue(a.value(0))
+		midLabel = formatValue(a.value(0.5))
+		maxLabel = formatValue(a.value(1))
+		line     = []rune(strings.Repeat(" ", width))
+	)
+	copy(line, []rune(minLabel))
+	if mid := width/2 - len(midLabel)/2; mid > len(minLabel) && mid+len(midLabel) < width-len(maxLabel) {
+		copy(line[mid:], []rune(midLabel))
+	}
+	if len(maxLabel) < width {
+		copy(line[width-len(maxLabel):], []rune(maxLabel))
+	}
+	return string(line)
+}
+
+// barCells returns a bar spanning from start to end, measured in
+// cells from the left. Bars extending right end in a partial cell,
+// while bars extending left are rounded to whole cells.
+func barCells(start, end float64, width int) string {
+	cells := []rune(strings.Repeat(" ", width))
+	if end < start {
+		for i := int(math.Round(end)); i < int(math.Round(start)) && i < width; i++ {
+			cells[i] = barEighths[8]
+		}
+		return strings.TrimRight(string(cells), " ")
+	}
+
+	full := int(end)
+	for i := int(math.Round(start)); i < full && i < width; i++ {
+		cells[i] = barEighths[8]
+	}
+	if eighths := int(math.Round((end - float64(full)) * 8)); eighths > 0 && full < width {
+		cells[full] = barEighths[eighths]
+	}
+	return strings.TrimRight(string(cells), " ")
+}
+
+func center(text string, width int) string {
+	pad := (width - len([]rune(text))) / 2
+	if pad <= 0 {
+		return text
+	}
+	return strings.Repeat(" ", pad) + text
+}
+
+func formatValue(v float64) string {
+	return strconv.FormatFloat(v, 'g', 3, 64)
+}
@@ -0,0 +1,220 @@
+package term
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ShawnROGrady/benchplot/plot/plotter"
+)
+
+func TestCanvas(t *testing.T) {
+	var tests = map[string]struct {
+		cols, rows   int
+		draw         func(c *canvas)
+		expectedRows []string
+	}{
+		"single_dots": {
+			cols: 2, rows: 1,
+			draw: func(c *canvas) {
+				c.set(0, 0, 0)
+				c.set(3, 3, 0)
+			},
+			expectedRows: []string{"⠁⢀"},
+		},
+		"horizontal_line": {
+			cols: 2, rows: 1,
+			draw: func(c *canvas) {
+				c.line(0, 3, 3, 3, 0)
+			},
+			expectedRows: []string{"⣀⣀"},
+		},
+		"vertical_line": {
+			cols: 1, rows: 2,
+			draw: func(c *canvas) {
+				c.line(1, 7, 1, 0, 0)
+			},
+			expectedRows: []string{"⢸", "⢸"},
+		},
+		"out_of_bounds": {
+			cols: 1, rows: 1,
+			draw: func(c *canvas) {
+				c.set(-1, 0, 0)
+				c.set(2, 4, 0)
+			},
+			expectedRows: []string{" "},
+		},
+	}
+
+	noColor := func(text string, color int) string { return text }
+	for testName, testCase := range tests {
+		t.Run(testName, func(t *testing.T) {
+			c := newCanvas(testCase.cols, testCase.rows)
+			testCase.draw(c)
+			for i, expected := range testCase.expectedRows {
+				if row := c.row(i, noColor); row != expected {
+					t.Errorf("unexpected row %d (expected = %q, actual = %q)", i, expected, row)
+				}
+			}
+		})
+	}
+}
+
+var writeTests = map[string]struct {
+	plot             func(p *Plotter) error
+	color            bool
+	cols, rows       int
+	expectedContents []string
+	expectErr        error
+}{
+	"line": {
+		plot: func(p *Plotter) error {
+			return p.PlotLine(map[string]plotter.NumericData{
+				"impl=a": {X: []float64{1, 3}, Y: []float64{0, 10}},
+				"impl=b": {X: []float64{1, 3}, Y: []float64{5, 5}},
+			}, "BenchmarkFoo", "n", "time (ns/op)", true)
+		},
+		cols: 40, rows: 16,
+		expectedContents: []string{
+			"BenchmarkFoo\n",
+			"time (ns/op)\n",
+			" 10 ┤",
+			"  5 ┤⠤⠤⠤",
+			"  0 ┤⡠⠔⠊⠁",
+			" 1                2                3\n",
+			"⣿ impl=a\n",
+			"⣿ impl=b\n",
+		},
+	},
+	"colored_legend": {
+		plot: func(p *Plotter) error {
+			return p.PlotScatter(map[string]plotter.NumericData{
+				"": {X: []float64{1, 2}, Y: []float64{1, 2}},
+			}, "BenchmarkFoo", "n", "y", true)
+		},
+		color: true,
+		cols:  40, rows: 12,
+		expectedContents: []string{"\x1b[31m⣿\x1b[0m BenchmarkFoo\n"},
+	},
+	"subtitle": {
+		plot: func(p *Plotter) error {
+			if err := p.SetSubtitle("goos: linux; goarch: amd64, arm64"); err != nil {
+				return err
+			}
+			return p.PlotScatter(map[string]plotter.NumericData{
+				"": {X: []float64{1, 2}, Y: []float64{1, 2}},
+			}, "BenchmarkFoo", "n", "y", true)
+		},
+		cols: 40, rows: 12,
+		expectedContents: []string{
+			"              BenchmarkFoo\n   goos: linux; goarch: amd64, arm64\n",
+		},
+	},
+	"too_small": {
+		plot: func(p *Plotter) error {
+			return p.PlotScatter(map[string]plotter.NumericData{
+				"": {X: []float64{1, 2}, Y: []float64{1, 2}},
+			}, "", "", "", true)
+		},
+		cols: 10, rows: 4,
+		expectErr: errors.New("10 columns and 4 rows is too small for a terminal plot"),
+	},
+	"bar": {
+		plot: func(p *Plotter) error {
+			return p.PlotBar(map[string]plotter.CategoricalData{
+				"impl=a": {X: []string{"quick", "merge"}, Y: []float64{10, 4}},
+				"impl=b": {X: []string{"quick", "heap"}, Y: []float64{5, 7.5}},
+			}, "BenchmarkSort", "algo", "time (ns/op)", true)
+		},
+		cols: 30, rows: 14,
+		expectedContents: []string{
+			"algo\n",
+			"quick ┤███████████████████████\n",
+			"      ┤███████████▌\n",
+			" heap ┤█████████████████▎\n",
+			"merge ┤█████████▎\n",
+			"      └───────────────────────\n",
+			"       0          5         10\n",
+			"            time (ns/op)\n",
+			"⣿ impl=a\n",
+			"⣿ impl=b\n",
+		},
+	},
+	"bar,too_small": {
+		plot: func(p *Plotter) error {
+			return p.PlotBar(map[string]plotter.CategoricalData{
+				"": {X: []string{"a", "b", "c"}, Y: []float64{1, 2, 3}},
+			}, "", "", "", true)
+		},
+		cols: 30, rows: 7,
+		expectErr: errors.New("30 columns and 7 rows is too small for a terminal plot"),
+	},
+	"bar,with_line": {
+		plot: func(p *Plotter) error {
+			if err := p.PlotBar(map[string]plotter.CategoricalData{"": {X: []string{"a"}, Y: []float64{1}}}, "", "", "", true); err != nil {
+				return err
+			}
+			return p.PlotLine(map[string]plotter.NumericData{"": {X: []float64{1}, Y: []float64{1}}}, "", "", "", true)
+		},
+		cols: 30, rows: 14,
+		expectErr: errors.New("bar plots cannot be drawn with other plots in the terminal"),
+	},
+	"box": {
+		plot: func(p *Plotter) error {
+			return p.PlotBox(map[string]plotter.DistributionData{}, "", "", "", true)
+		},
+		expectErr: ErrUnsupportedPlot,
+	},
+}
+
+func TestWrite(t *testing.T) {
+	for testName, testCase := range writeTests {
+		t.Run(testName, func(t *testing.T) {
+			p := &Plotter{Color: testCase.color}
+			var out strings.Builder
+			err := testCase.plot(p)
+			if err == nil {
+				err = p.Write(&out, testCase.cols, testCase.rows)
+			}
+			if err != nil {
+				if testCase.expectErr == nil || err.Error() != testCase.expectErr.Error() {
+					t.Errorf("unexpected error (expected = %v, actual = %s)", testCase.expectErr, err)
+				}
+				return
+			}
+			if testCase.expectErr != nil {
+				t.Fatalf("unexpectedly no error")
+			}
+
+			for _, expected := range testCase.expectedContents {
+				if !strings.Contains(out.String(), expected) {
+					t.Errorf("expected output to contain %q\noutput:\n%s", expected, out.String())
+				}
+			}
+		})
+	}
+}
+
+func TestBarCells(t *testing.T) {
+	var tests = map[string]struct {
+		start, end  float64
+		width       int
+		expectedBar string
+	}{
+		"whole_cells":   {start: 0, end: 3, width: 5, expectedBar: "███"},
+		"partial_cell":  {start: 0, end: 2.5, width: 5, expectedBar: "██▌"},
+		"empty":         {start: 0, end: 0, width: 5, expectedBar: ""},
+		"full_width":    {start: 0, end: 5, width: 5, expectedBar: "█████"},
+		"offset_start":  {start: 2, end: 4.25, width: 5, expectedBar: "  ██▎"},
+		"extends_left":  {start: 3, end: 1.2, width: 5, expectedBar: " ██"},
+		"rounds_to_end": {start: 0, end: 1.99, width: 5, expectedBar: "██"},
+	}
+
+	for testName, testCase := range tests {
+		t.Run(testName, func(t *testing.T) {
+			if bar := barCells(testCase.start, testCase.end, testCase.width); bar != testCase.expectedBar {
+				t.Errorf("unexpected bar (expected = %q, actual = %q)", testCase.expectedBar, bar)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package term
+
+import (
+	"math"
+
+	"github.com/ShawnROGrady/benchplot/plot/plotter"
+)
+
+// axis maps data values along one dimension of the figure to the
+// fraction of the way along the axis.
+type axis struct {
+	scale  plotter.Scale
+	lo, hi float64 // the transformed range
+}
+
+// newAxis returns an axis covering the values.
+func newAxis(scale plotter.Scale, vals []float64) axis {
+	a := axis{scale: scale}
+	if len(vals) == 0 {
+		a.lo, a.hi = 0, 1
+		return a
+	}
+	a.lo, a.hi = a.transform(vals[0]), a.transform(vals[0])
+	for _, v := range vals[1:] {
+		a.lo = math.Min(a.lo, a.transform(v))
+		a.hi = math.Max(a.hi, a.transform(v))
+	}
+	if a.lo == a.hi {
+		a.lo, a.hi = a.lo-0.5, a.hi+0.5
+	}
+	return a
+}
+
+// transform returns the value in the (possibly logarithmic) space
+// the axis is drawn in.
+func (a axis) transform(v float64) float64 {
+	switch a.scale {
+	case plotter.Log2Scale:
+		return math.Log2(v)
+	case plotter.Log10Scale:
+		return math.Log10(v)
+	default:
+		return v
+	}
+}
+
+// frac returns the fraction of the way along the axis of the value.
+func (a axis) frac(v float64) float64 {
+	return (a.transform(v) - a.lo) / (a.hi - a.lo)
+}
+
+// value returns the data value the fraction of the way along the axis.
+func (a axis) value(frac float64) float64 {
+	v := a.lo + frac*(a.hi-a.lo)
+	switch a.scale {
+	case plotter.Log2Scale:
+		return math.Pow(2, v)
+	case plotter.Log10Scale:
+		return math.Pow(10, v)
+	default:
+		return v
+	}
+}